@@ -0,0 +1,94 @@
+package app
+
+import (
+	"crypto/subtle"
+	"go-skeleton/config"
+	httpcommon "go-skeleton/internal/common/http"
+	x "go-skeleton/pkg/errors/entity"
+	httperr "go-skeleton/pkg/errors/http"
+	"go-skeleton/pkg/logger"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type logLevelRequest struct {
+	Level    string `json:"level" binding:"required"`
+	Duration string `json:"duration"`
+}
+
+// registerAdminRoutes registers the token protected admin endpoints to the provided router
+func registerAdminRoutes(router *gin.Engine) {
+	admin := router.Group("/admin", adminAuthMiddleware(config.Server.AdminToken))
+	admin.GET("/log-level", getLogLevel)
+	admin.PUT("/log-level", putLogLevel)
+}
+
+// adminAuthMiddleware requires "Authorization: Bearer <token>", admin endpoints are closed when no token is configured
+func adminAuthMiddleware(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			httpcommon.ResponseError(c, x.NewWithCode(httperr.CodeHTTPForbidden, "admin token is not configured"))
+			c.Abort()
+			return
+		}
+
+		provided, ok := bearerToken(c.GetHeader(httpcommon.HeaderAuthorization.String()))
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			httpcommon.ResponseError(c, x.NewWithCode(httperr.CodeHTTPUnauthorized, "invalid admin token"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// bearerToken returns the token of a "Bearer <token>" header, the scheme is case-insensitive
+func bearerToken(header string) (string, bool) {
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	return token, true
+}
+
+func getLogLevel(c *gin.Context) {
+	httpcommon.ResponseSuccess(c, http.StatusOK, "success", logger.GetLevel(), nil)
+}
+
+// putLogLevel changes the log level, a duration makes the change temporary
+func putLogLevel(c *gin.Context) {
+	var req logLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpcommon.ResponseError(c, x.WrapWithCode(err, httperr.CodeHTTPUnmarshal, "decode log level request"))
+		return
+	}
+
+	level, err := logger.ParseLevel(req.Level)
+	if err != nil {
+		httpcommon.ResponseError(c, x.NewWithCode(httperr.CodeHTTPBadRequestCustom, "invalid log level %q", req.Level))
+		return
+	}
+
+	if req.Duration == "" {
+		logger.SetLevel(level)
+	} else {
+		duration, err := time.ParseDuration(req.Duration)
+		if err != nil || duration <= 0 {
+			httpcommon.ResponseError(c, x.NewWithCode(httperr.CodeHTTPBadRequestCustom, "invalid duration %q", req.Duration))
+			return
+		}
+		logger.SetLevelFor(level, duration)
+	}
+
+	logger.FromContext(c.Request.Context()).Info("Log level changed",
+		zap.String("level", level.String()),
+		zap.String("duration", req.Duration),
+	)
+
+	httpcommon.ResponseSuccess(c, http.StatusOK, "success", logger.GetLevel(), nil)
+}
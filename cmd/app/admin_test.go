@@ -0,0 +1,89 @@
+package app
+
+import (
+	"encoding/json"
+	"go-skeleton/config"
+	"go-skeleton/pkg/logger"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func newAdminTestRouter(t *testing.T, token string) *gin.Engine {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	previous := config.Server.AdminToken
+	config.Server.AdminToken = token
+	t.Cleanup(func() {
+		config.Server.AdminToken = previous
+		logger.SetLevel(zapcore.InfoLevel)
+	})
+
+	router := gin.New()
+	registerAdminRoutes(router)
+	return router
+}
+
+func adminRequest(router *gin.Engine, method, body, token string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, "/admin/log-level", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAdminLogLevel_RequiresToken(t *testing.T) {
+	router := newAdminTestRouter(t, "secret")
+
+	assert.Equal(t, http.StatusUnauthorized, adminRequest(router, http.MethodGet, "", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, adminRequest(router, http.MethodGet, "", "wrong").Code)
+	assert.Equal(t, http.StatusOK, adminRequest(router, http.MethodGet, "", "secret").Code)
+}
+
+func TestAdminLogLevel_RequiresBearerScheme(t *testing.T) {
+	router := newAdminTestRouter(t, "secret")
+
+	request := func(header string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/admin/log-level", nil)
+		req.Header.Set("Authorization", header)
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, request("secret"))
+	assert.Equal(t, http.StatusUnauthorized, request("Basic secret"))
+	assert.Equal(t, http.StatusOK, request("bearer secret"))
+}
+
+func TestAdminLogLevel_DisabledWithoutToken(t *testing.T) {
+	router := newAdminTestRouter(t, "")
+
+	assert.Equal(t, http.StatusForbidden, adminRequest(router, http.MethodGet, "", "").Code)
+}
+
+func TestAdminLogLevel_Put(t *testing.T) {
+	router := newAdminTestRouter(t, "secret")
+
+	w := adminRequest(router, http.MethodPut, `{"level":"debug","duration":"1m"}`, "secret")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Data logger.LevelStatus `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "debug", resp.Data.Level)
+	assert.NotNil(t, resp.Data.ExpiresAt)
+
+	assert.Equal(t, http.StatusBadRequest, adminRequest(router, http.MethodPut, `{"level":"loud"}`, "secret").Code)
+	assert.Equal(t, http.StatusBadRequest, adminRequest(router, http.MethodPut, `{"level":"debug","duration":"soon"}`, "secret").Code)
+}
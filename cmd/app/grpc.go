@@ -0,0 +1,53 @@
+package app
+
+import (
+	"context"
+	"go-skeleton/pkg/database"
+	"go-skeleton/pkg/logger"
+	"go-skeleton/pkg/requestid"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// NewGlobalGRPCServer creates and configures the global gRPC server with interceptors and common services
+func NewGlobalGRPCServer() (*grpc.Server, *health.Server) {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			recoveryUnaryInterceptor(),                // Equivalent to gin.Recovery
+			requestid.UnaryServerInterceptor(),        // Generates or propagates the x-request-id correlation ID
+			logger.UnaryServerInterceptor(),           // Our custom logging interceptor
+			database.PinScopeUnaryServerInterceptor(), // Lets a call read its own writes from the primary
+		),
+	)
+
+	// Standard gRPC health protocol, the empty service name reports the overall server status
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	// Server reflection for tooling such as grpcurl
+	reflection.Register(server)
+
+	return server, healthServer
+}
+
+func recoveryUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("gRPC handler panic recovered",
+					zap.String("method", info.FullMethod),
+					zap.Any("panic", r),
+				)
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
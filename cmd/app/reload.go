@@ -0,0 +1,110 @@
+package app
+
+import (
+	"context"
+	"go-skeleton/config"
+	"go-skeleton/pkg/database"
+	"go-skeleton/pkg/logger"
+	"go-skeleton/pkg/redact"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// httpTimeouts are applied to every new request so READ_TIMEOUT_MS and WRITE_TIMEOUT_MS can change without a restart
+var httpTimeouts struct {
+	read  atomic.Int64
+	write atomic.Int64
+}
+
+// registerReloadListeners applies reloaded configuration keys to the subsystems that support it
+func registerReloadListeners() {
+	storeHTTPTimeouts(config.Server)
+
+	config.OnChange(func(change config.Change) {
+		level, err := logger.ParseLevel(change.New.Logger.Level)
+		if err != nil {
+			logger.Error("Invalid log level in configuration", zap.String("level", change.New.Logger.Level), zap.Error(err))
+			return
+		}
+		logger.SetLevel(level)
+	}, "LOG_LEVEL")
+
+	config.OnChange(func(change config.Change) {
+		if err := redact.Init(change.New.Redact); err != nil {
+			logger.Error("Failed to reload redaction rules", zap.Error(err))
+		}
+	}, "REDACT_FIELDS", "REDACT_PATTERNS")
+
+	config.OnChange(func(change config.Change) {
+		storeHTTPTimeouts(change.New.Server)
+	}, "READ_TIMEOUT_MS", "WRITE_TIMEOUT_MS")
+
+	config.OnChange(func(change config.Change) {
+		database.SetSlowQueryThreshold(change.New.Database.SlowQueryThreshold)
+	}, "DB_SLOW_QUERY_THRESHOLD_MS")
+}
+
+func storeHTTPTimeouts(cfg config.ServerConfig) {
+	httpTimeouts.read.Store(int64(cfg.ReadTimeout))
+	httpTimeouts.write.Store(int64(cfg.WriteTimeout))
+}
+
+// timeoutMiddleware moves the connection deadlines of the request to the current HTTP timeouts
+func timeoutMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		controller := http.NewResponseController(c.Writer)
+		now := time.Now()
+		if timeout := time.Duration(httpTimeouts.read.Load()); timeout > 0 {
+			_ = controller.SetReadDeadline(now.Add(timeout))
+		}
+		if timeout := time.Duration(httpTimeouts.write.Load()); timeout > 0 {
+			_ = controller.SetWriteDeadline(now.Add(timeout))
+		}
+		c.Next()
+	}
+}
+
+// watchConfigReload reloads the configuration on SIGHUP and, when CONFIG_WATCH is enabled, whenever the file changes
+func watchConfigReload(ctx context.Context) {
+	if config.App.Watch {
+		if err := config.Watch(reportReload); err != nil {
+			logger.Error("Failed to watch configuration files", zap.Error(err))
+		}
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-hup:
+			reportReload(config.Reload())
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func reportReload(change config.Change, err error) {
+	if err != nil {
+		logger.Error("Failed to reload configuration, keeping the current one", zap.Error(err))
+		return
+	}
+
+	if len(change.RestartRequired) > 0 {
+		logger.Warn("Configuration keys changed that require a restart, keeping their current values",
+			zap.Strings("keys", change.RestartRequired),
+		)
+	}
+	if len(change.Changed) > 0 {
+		logger.Info("Configuration reloaded", zap.Strings("keys", change.Changed))
+	}
+}
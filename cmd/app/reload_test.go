@@ -0,0 +1,48 @@
+package app
+
+import (
+	"go-skeleton/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeoutMiddleware_RaisedWriteTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	previous := config.Server
+	config.Server.ReadTimeout = 50 * time.Millisecond
+	config.Server.WriteTimeout = 50 * time.Millisecond
+	storeHTTPTimeouts(config.Server)
+	t.Cleanup(func() {
+		config.Server = previous
+		storeHTTPTimeouts(previous)
+	})
+
+	router := gin.New()
+	router.Use(timeoutMiddleware())
+	router.GET("/slow", func(c *gin.Context) {
+		time.Sleep(100 * time.Millisecond)
+		c.String(http.StatusOK, "done")
+	})
+
+	server := httptest.NewUnstartedServer(nil)
+	server.Config = newHTTPServer(0, router)
+	server.Start()
+	t.Cleanup(server.Close)
+
+	// The startup timeout cuts the slow response off
+	_, err := http.Get(server.URL + "/slow")
+	assert.Error(t, err)
+
+	// A reloaded timeout applies to the next request without restarting the server
+	storeHTTPTimeouts(config.ServerConfig{ReadTimeout: time.Second, WriteTimeout: time.Second})
+	resp, err := http.Get(server.URL + "/slow")
+	if assert.NoError(t, err) {
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+}
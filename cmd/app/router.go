@@ -2,28 +2,61 @@ package app
 
 import (
 	"go-skeleton/config"
+	"go-skeleton/pkg/database"
 	"go-skeleton/pkg/logger"
+	"go-skeleton/pkg/metrics"
+	"go-skeleton/pkg/requestid"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 // NewGlobalRouter creates and configures the global router with middleware and common settings
 func NewGlobalRouter() *gin.Engine {
-	// Set Gin mode based on log level for better performance in production
-	if config.Logger.Level == "debug" || config.Logger.Development {
-		gin.SetMode(gin.DebugMode)
-	} else {
-		gin.SetMode(gin.ReleaseMode)
-	}
+	// Gin mode is configured on its own so changing the log level at runtime does not affect it
+	gin.SetMode(config.Server.GinMode)
 
 	router := gin.New()
 
 	// Add global middleware
-	router.Use(gin.Recovery())             // Equivalent to Chi's Recoverer
-	router.Use(logger.LoggingMiddleware()) // Our custom logging middleware
+	router.Use(gin.Recovery())                                 // Equivalent to Chi's Recoverer
+	router.Use(requestid.GinMiddleware())                      // Generates or propagates the x-request-id correlation ID
+	router.Use(otelgin.Middleware(config.Tracing.ServiceName)) // Extracts traceparent and starts the request span
+	router.Use(logger.LoggingMiddleware())                     // Our custom logging middleware
+	router.Use(timeoutMiddleware())                            // Applies the current read and write timeouts
+	router.Use(database.PinScopeGinMiddleware())               // Lets a request read its own writes from the primary
+
+	if config.Metrics.Enabled {
+		router.Use(metrics.GinMetricsMiddleware()) // Request count and latency per route template
+
+		// Serve metrics on the main port unless a dedicated admin port is configured
+		if config.Server.AdminPort == 0 {
+			router.GET(config.Metrics.Path, gin.WrapH(metrics.Handler()))
+		}
+	}
+
+	if config.Server.AdminPort == 0 {
+		registerAdminRoutes(router)
+	}
 
 	// Static file serving for docs
 	router.Static("/docs", config.App.DocsPath)
 
 	return router
 }
+
+// NewAdminRouter creates the router served on the dedicated admin port
+func NewAdminRouter() *gin.Engine {
+	router := gin.New()
+
+	router.Use(gin.Recovery())
+	router.Use(timeoutMiddleware())
+
+	if config.Metrics.Enabled {
+		router.GET(config.Metrics.Path, gin.WrapH(metrics.Handler()))
+	}
+
+	registerAdminRoutes(router)
+
+	return router
+}
@@ -2,67 +2,205 @@ package app
 
 import (
 	"context"
+	"errors"
 	"go-skeleton/config"
 	"go-skeleton/pkg/logger"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
+	"sync"
 	"syscall"
+	"time"
 
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
 )
 
+const defaultShutdownTimeout = 10 * time.Second
+
 type Server struct {
-	server *http.Server
+	server       *http.Server
+	adminServer  *http.Server
+	grpcServer   *grpc.Server
+	grpcAddr     string
+	healthServer *health.Server
 }
 
 func New() *Server {
-	handler := SetupRouter()
+	modules := SetupModules()
+	handler := SetupRouter(modules)
+	grpcServer, healthServer := SetupGRPCServer(modules)
 
 	server := &Server{
-		server: &http.Server{
-			Addr:         ":" + strconv.Itoa(config.Server.Port),
-			Handler:      handler,
-			ReadTimeout:  config.Server.ReadTimeout,
-			WriteTimeout: config.Server.WriteTimeout,
-		},
+		server:       newHTTPServer(config.Server.Port, handler),
+		grpcServer:   grpcServer,
+		grpcAddr:     ":" + strconv.Itoa(config.Server.GRPCPort),
+		healthServer: healthServer,
+	}
+
+	// Admin endpoints get their own listener only when a dedicated port is configured
+	if config.Server.AdminPort > 0 {
+		server.adminServer = newHTTPServer(config.Server.AdminPort, NewAdminRouter())
 	}
 
 	logger.Info("Server created",
 		zap.String("addr", server.server.Addr),
+		zap.String("grpc_addr", server.grpcAddr),
+		zap.Int("admin_port", config.Server.AdminPort),
 		zap.Duration("read_timeout", config.Server.ReadTimeout),
 		zap.Duration("write_timeout", config.Server.WriteTimeout),
+		zap.Duration("shutdown_timeout", config.Server.ShutdownTimeout),
+		zap.Duration("shutdown_delay", config.Server.ShutdownDelay),
 	)
 
 	return server
 }
 
+// newHTTPServer bounds only the request headers and idle connections with the startup READ_TIMEOUT_MS, the read and
+// write deadlines of each request are set by timeoutMiddleware so reloading the timeouts takes effect
+func newHTTPServer(port int, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              ":" + strconv.Itoa(port),
+		Handler:           handler,
+		ReadHeaderTimeout: config.Server.ReadTimeout,
+		IdleTimeout:       config.Server.ReadTimeout,
+	}
+}
+
 func (s *Server) Start(ctx context.Context, cancel context.CancelFunc) {
 	go s.waitForShutDown(ctx, cancel)
+	go watchConfigReload(ctx)
 
 	logger.Info("Starting HTTP server", zap.String("addr", s.server.Addr))
 
+	if err := serveHTTP(s.server, cancel); err != nil {
+		logger.Error("HTTP listen error", zap.Error(err))
+		cancel()
+		return
+	}
+
+	if s.adminServer != nil {
+		logger.Info("Starting admin HTTP server", zap.String("addr", s.adminServer.Addr))
+
+		if err := serveHTTP(s.adminServer, cancel); err != nil {
+			logger.Error("Admin HTTP listen error", zap.Error(err))
+			cancel()
+			return
+		}
+	}
+
+	logger.Info("Starting gRPC server", zap.String("addr", s.grpcAddr))
+
+	grpcListener, err := net.Listen("tcp", s.grpcAddr)
+	if err != nil {
+		logger.Error("gRPC listen error", zap.Error(err))
+		cancel()
+		return
+	}
+
+	go func() {
+		err := s.grpcServer.Serve(grpcListener)
+		if err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			logger.Error("gRPC server error", zap.Error(err))
+			cancel()
+		}
+	}()
+
+	// All listeners are bound, the startup probe can now succeed
+	container.Health.MarkStarted()
+}
+
+// serveHTTP binds the server address and serves it in the background
+func serveHTTP(server *http.Server, cancel context.CancelFunc) error {
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		return err
+	}
+
 	go func() {
-		err := s.server.ListenAndServe()
+		err := server.Serve(listener)
 		if err != nil && err != http.ErrServerClosed {
-			logger.Error("Server error", zap.Error(err))
+			logger.Error("Server error", zap.String("addr", server.Addr), zap.Error(err))
 			cancel()
 		}
 	}()
+
+	return nil
 }
 
 func (s *Server) waitForShutDown(ctx context.Context, cancel context.CancelFunc) {
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
 
-	<-stop
-	logger.Info("Shutdown signal received, stopping server gracefully...")
+	select {
+	case <-stop:
+		logger.Info("Shutdown signal received, stopping server gracefully...")
+	case <-ctx.Done():
+		logger.Info("Server context cancelled, stopping server gracefully...")
+	}
+
+	// Fail readiness first so load balancers stop routing new traffic before the listeners close
+	container.Health.MarkShuttingDown()
+	s.healthServer.Shutdown()
+	if delay := config.Current().Server.ShutdownDelay; delay > 0 {
+		logger.Info("Waiting for traffic to drain", zap.Duration("shutdown_delay", delay))
+		time.Sleep(delay)
+	}
+
+	// Detach from the server context so draining is bounded only by the shutdown timeout
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.WithoutCancel(ctx), s.shutdownTimeout())
+	defer shutdownCancel()
+
+	var wg sync.WaitGroup
+
+	for _, server := range []*http.Server{s.server, s.adminServer} {
+		if server == nil {
+			continue
+		}
 
-	if err := s.server.Shutdown(ctx); err != nil {
-		logger.Error("Server shutdown error", zap.Error(err))
+		wg.Add(1)
+		go func(server *http.Server) {
+			defer wg.Done()
+			if err := server.Shutdown(shutdownCtx); err != nil {
+				logger.Error("Server shutdown error", zap.String("addr", server.Addr), zap.Error(err))
+			}
+		}(server)
 	}
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.stopGRPC(shutdownCtx)
+	}()
+
+	wg.Wait()
+
 	logger.Info("Server stopped")
 	cancel() // call the cancelFunc to close the shared interrupt channel between REST and gRPC and shutdown both servers
 }
+
+// stopGRPC drains in-flight RPCs and forces the server to stop once ctx expires
+func (s *Server) stopGRPC(ctx context.Context) {
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		logger.Warn("gRPC graceful stop timed out, forcing stop")
+		s.grpcServer.Stop()
+	}
+}
+
+func (s *Server) shutdownTimeout() time.Duration {
+	if timeout := config.Current().Server.ShutdownTimeout; timeout > 0 {
+		return timeout
+	}
+	return defaultShutdownTimeout
+}
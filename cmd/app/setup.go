@@ -1,43 +1,98 @@
 package app
 
 import (
+	"context"
 	"go-skeleton/config"
 	dicontainer "go-skeleton/container"
 	"go-skeleton/internal/ping"
 	"go-skeleton/pkg/cache"
 	"go-skeleton/pkg/database"
 	"go-skeleton/pkg/logger"
+	"go-skeleton/pkg/metrics"
+	"go-skeleton/pkg/redact"
+	"go-skeleton/pkg/tracing"
+	"log"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
 )
 
 var container dicontainer.Container
 
+// Module is implemented by every feature module that exposes REST routes and gRPC services
+type Module interface {
+	RegisterRoutes(router *gin.Engine)
+	RegisterGRPCServices(server grpc.ServiceRegistrar)
+}
+
 func Init() {
-	config.Init()
+	if err := config.Init(); err != nil {
+		log.Fatalf("failed to load configuration: %v", err)
+	}
+	if err := redact.Init(config.Redact); err != nil {
+		log.Fatalf("failed to initialize redaction: %v", err)
+	}
 	logger.Init(config.Logger)
-	database.Init(config.Database)
-	cache.Init(config.RedisCache)
+	registerReloadListeners()
+	tracing.Init(config.Tracing)
+	database.Init(config.Database, config.Startup)
+	cache.Init(config.RedisCache, config.Startup)
+
+	// Initialize metrics and publish the connection pool stats
+	if config.Metrics.Enabled {
+		metrics.Init(config.Metrics)
+		metrics.RegisterDBStats(database.DBConn.DB, config.Database.Name)
+		for _, replica := range database.DBCluster.Replicas() {
+			metrics.RegisterDBStats(replica.DB.DB, config.Database.Name+"@"+replica.Name)
+		}
+		metrics.RegisterRedisPoolStats(cache.RedisClient)
+	}
 
 	// Initialize dependency injection container
 	container = dicontainer.NewContainer()
 }
 
-func SetupRouter() *gin.Engine {
+func SetupModules() []Module {
+	// Initialize modules with dependency injection
+	pingModule := ping.NewModule(&container)
+
+	return []Module{
+		&pingModule,
+	}
+}
+
+func SetupRouter(modules []Module) *gin.Engine {
 	// Create global router with middleware and common settings
 	router := NewGlobalRouter()
 
-	// Initialize modules with dependency injection
-	pingModule := ping.NewModule(&container)
+	// Register liveness, readiness and startup probes
+	container.Health.RegisterRoutes(router)
 
 	// Register module routes
-	pingModule.RegisterRoutes(router)
+	for _, module := range modules {
+		module.RegisterRoutes(router)
+	}
 
 	return router
 }
 
+func SetupGRPCServer(modules []Module) (*grpc.Server, *health.Server) {
+	// Create global gRPC server with interceptors and common services
+	server, healthServer := NewGlobalGRPCServer()
+
+	// Register module services
+	for _, module := range modules {
+		module.RegisterGRPCServices(server)
+	}
+
+	return server, healthServer
+}
+
 func ShutDown() {
-	logger.Sync() // Flush any buffered logs
+	tracing.Shutdown(context.Background()) // Flush any pending spans
+	logger.Sync()                          // Flush any buffered logs
+	logger.Close()                         // Close rotating log files
 	database.CloseDB()
 	cache.CloseCache()
 }
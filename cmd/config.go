@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-skeleton/config"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatYAML     = "yaml"
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+	FormatSample   = "sample"
+)
+
+// PrintConfig writes the effective configuration with the source of every key, or only the keys that differ
+// from diffFile when it is set. diffFile is compared as written, without overlays or environment variables.
+// Validation problems are returned after printing.
+func PrintConfig(w io.Writer, format, diffFile string) error {
+	if format != FormatYAML && format != FormatJSON {
+		return fmt.Errorf("unknown format %q, use %s or %s", format, FormatYAML, FormatJSON)
+	}
+
+	cfg, loadErr := config.Load()
+	if cfg == nil {
+		return loadErr
+	}
+	entries := config.Describe(cfg)
+
+	if diffFile == "" {
+		if err := writeEntries(w, format, entries); err != nil {
+			return err
+		}
+		return loadErr
+	}
+
+	// The other file is read on its own, environment variables would otherwise hide its differences
+	other, otherErr := config.LoadFile(diffFile)
+	if other == nil {
+		return otherErr
+	}
+
+	if err := writeDiff(w, format, config.Diff(entries, config.DescribeFile(other, diffFile))); err != nil {
+		return err
+	}
+	return errors.Join(loadErr, otherErr)
+}
+
+// PrintSchema writes the JSON Schema, the markdown reference or the sample file generated from the config structs
+func PrintSchema(w io.Writer, format string) error {
+	switch format {
+	case FormatJSON:
+		schema, err := config.JSONSchema()
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", schema)
+		return err
+	case FormatMarkdown:
+		_, err := io.WriteString(w, config.Markdown())
+		return err
+	case FormatSample:
+		_, err := io.WriteString(w, config.Sample())
+		return err
+	default:
+		return fmt.Errorf("unknown format %q, use %s, %s or %s", format, FormatJSON, FormatMarkdown, FormatSample)
+	}
+}
+
+// writeEntries writes JSON, or YAML grouped by section with the source of each key as a comment
+func writeEntries(w io.Writer, format string, entries []config.Entry) error {
+	if format == FormatJSON {
+		return writeJSON(w, entries)
+	}
+
+	var b strings.Builder
+	section := ""
+	for _, entry := range entries {
+		if entry.Section != section {
+			if section != "" {
+				b.WriteString("\n")
+			}
+			section = entry.Section
+			fmt.Fprintf(&b, "# %s\n", section)
+		}
+
+		source := entry.Source
+		if entry.Reference != "" {
+			source += " via " + entry.Reference
+		}
+		fmt.Fprintf(&b, "%s: %s  # %s\n", entry.Key, yamlValue(entry.Value), source)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeDiff writes JSON, or YAML with the current and the other value of each differing key
+func writeDiff(w io.Writer, format string, diff []config.DiffEntry) error {
+	if format == FormatJSON {
+		return writeJSON(w, diff)
+	}
+
+	if len(diff) == 0 {
+		_, err := io.WriteString(w, "# no differences\n")
+		return err
+	}
+
+	var b strings.Builder
+	for _, entry := range diff {
+		fmt.Fprintf(&b, "%s: %s -> %s  # %s -> %s\n",
+			entry.Key, yamlValue(entry.Value), yamlValue(entry.OtherValue), entry.Source, entry.OtherSource)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeJSON(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// yamlValue renders a scalar as YAML, lists use the comma separated form accepted by the loader
+func yamlValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case []string:
+		return strconv.Quote(strings.Join(v, ","))
+	case nil:
+		return `""`
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"go-skeleton/pkg/database"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// ForceCommand returns the migrate force command calling force with the requested version. The version is given
+// as an argument or with --version, which is the way to pass -1 as a bare -1 is read as a flag.
+func ForceCommand(force func(version int) error) *cli.Command {
+	return &cli.Command{
+		Name:      "force",
+		Usage:     "set the version and clear the dirty flag without running migrations, --version -1 means none applied",
+		ArgsUsage: "<version>",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "version",
+				Usage: "version to set, -1 means none applied",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			version, err := forceVersion(c)
+			if err != nil {
+				return cli.Exit(err.Error(), 1)
+			}
+			return force(version)
+		},
+	}
+}
+
+// forceVersion reads the version of the force command from --version or the first argument
+func forceVersion(c *cli.Context) (int, error) {
+	if c.IsSet("version") {
+		if c.Args().Present() {
+			return 0, fmt.Errorf("give the version either as an argument or with --version")
+		}
+		if version := c.Int("version"); version >= -1 {
+			return version, nil
+		}
+		return 0, fmt.Errorf("invalid version %d", c.Int("version"))
+	}
+
+	if !c.Args().Present() {
+		return 0, fmt.Errorf("a version is required, use --version -1 to mark no migration as applied")
+	}
+	version, err := strconv.Atoi(c.Args().First())
+	if err != nil || version < -1 {
+		return 0, fmt.Errorf("invalid version %q", c.Args().First())
+	}
+	return version, nil
+}
+
+// PrintMigrationStatus writes the current version, the dirty flag and the pending migrations
+func PrintMigrationStatus(w io.Writer, status database.MigrationStatus) {
+	if status.Applied {
+		fmt.Fprintf(w, "version: %d\n", status.Version)
+	} else {
+		fmt.Fprintln(w, "version: none")
+	}
+	fmt.Fprintf(w, "dirty: %t\n", status.Dirty)
+
+	if len(status.Pending) == 0 {
+		fmt.Fprintln(w, "pending: none")
+		return
+	}
+	fmt.Fprintf(w, "pending: %d\n", len(status.Pending))
+	for _, file := range status.Pending {
+		if file.Go {
+			fmt.Fprintf(w, "  %d_%s (go)\n", file.Version, file.Name)
+		} else {
+			fmt.Fprintf(w, "  %d_%s\n", file.Version, file.Name)
+		}
+	}
+}
+
+// Confirm asks question on out and reports whether the answer read from in is yes
+func Confirm(in io.Reader, out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%s [y/N]: ", question)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+)
+
+// runForce runs the force command with args and returns the version it forced
+func runForce(args ...string) (int, error) {
+	forced := 0
+	app := &cli.App{
+		Name:      "skeleton",
+		Writer:    io.Discard,
+		ErrWriter: io.Discard,
+		Commands: []*cli.Command{ForceCommand(func(version int) error {
+			forced = version
+			return nil
+		})},
+		ExitErrHandler: func(*cli.Context, error) {},
+	}
+	err := app.Run(append([]string{"skeleton", "force"}, args...))
+	return forced, err
+}
+
+func TestForceCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{"argument", []string{"20240101000000"}, 20240101000000},
+		{"flag", []string{"--version", "20240101000000"}, 20240101000000},
+		{"flag none applied", []string{"--version", "-1"}, -1},
+		{"flag with equals", []string{"--version=-1"}, -1},
+		{"argument after separator", []string{"--", "-1"}, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := runForce(tt.args...)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, version)
+		})
+	}
+}
+
+func TestForceCommand_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"missing", nil},
+		{"not a number", []string{"latest"}},
+		{"below none applied", []string{"--version", "-2"}},
+		{"both", []string{"--version", "3", "4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := runForce(tt.args...)
+
+			assert.Error(t, err)
+		})
+	}
+}
@@ -3,11 +3,21 @@ package cmd
 import (
 	"context"
 	"go-skeleton/cmd/app"
+	"go-skeleton/config"
+	"go-skeleton/migrations"
+	"go-skeleton/pkg/database"
+	"go-skeleton/pkg/logger"
+
+	"go.uber.org/zap"
 )
 
 func StartServer(c context.Context) {
 	ctx, cancel := context.WithCancel(c)
 
+	if config.Migration.AutoMigrate {
+		autoMigrate(ctx)
+	}
+
 	startServer(ctx, cancel)
 
 	<-ctx.Done()
@@ -17,3 +27,15 @@ func startServer(ctx context.Context, cancel context.CancelFunc) {
 	s := app.New()
 	s.Start(ctx, cancel)
 }
+
+// autoMigrate applies the pending migrations before serving, the server does not start when they fail
+func autoMigrate(ctx context.Context) {
+	migration, err := database.InitMigration(config.Database, config.Migration, migrations.FS)
+	if err != nil {
+		logger.Fatal("failed to initialize auto migration", zap.Error(err))
+	}
+
+	if err := migration.AutoMigrate(ctx, config.Migration.AutoMigrateTimeout); err != nil {
+		logger.Fatal("failed to auto migrate the database", zap.Error(err))
+	}
+}
@@ -1,11 +1,16 @@
 package config
 
 type AppConfig struct {
-	DocsPath string
+	DocsPath string `mapstructure:"DOCS_PATH" validate:"required" desc:"Directory of the Swagger UI and OpenAPI files served under /docs" example:"./docs"`
+	// Watch reloads the configuration file when it changes, SIGHUP reloads it either way
+	Watch bool `mapstructure:"CONFIG_WATCH" default:"true" desc:"Reload the configuration when one of its files changes, SIGHUP reloads it either way"`
+	Debug DebugConfig
 }
 
-var App AppConfig
-
-func initAppConfig() {
-	App.DocsPath = mustGetString("DOCS_PATH")
+// DebugConfig restricts which callers may receive raw errors through the x-app-debug header
+type DebugConfig struct {
+	AllowedCIDRs []string `mapstructure:"APP_DEBUG_ALLOWED_CIDRS" validate:"cidr" reload:"live" desc:"Comma separated CIDRs or IPs whose x-app-debug requests receive raw errors" example:"127.0.0.1/32"`
+	Token        string   `mapstructure:"APP_DEBUG_TOKEN" reload:"live" secret:"true" desc:"Token of the x-app-debug-token header that enables raw errors from any address"`
 }
+
+var App AppConfig
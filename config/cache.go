@@ -2,36 +2,19 @@ package config
 
 import (
 	"time"
-
-	"github.com/spf13/viper"
 )
 
 type CacheConfig struct {
-	Host         string
-	Username     string
-	Password     string
-	DialTimeout  time.Duration
-	ReadTimeout  time.Duration
-	WriteTimeout time.Duration
-	IdleTimeout  time.Duration
-	DB           int
-	Port         int
-	PoolSize     int
+	Host         string        `mapstructure:"REDIS_HOST" validate:"required,hostname" desc:"Redis host" example:"localhost"`
+	Username     string        `mapstructure:"REDIS_USERNAME" desc:"Redis ACL user name"`
+	Password     string        `mapstructure:"REDIS_PASSWORD" secret:"true" desc:"Redis password"`
+	DialTimeout  time.Duration `mapstructure:"REDIS_DIAL_TIMEOUT" validate:"min=0s" desc:"Timeout for establishing Redis connections" example:"200ms"`
+	ReadTimeout  time.Duration `mapstructure:"REDIS_READ_TIMEOUT" validate:"min=0s" desc:"Timeout of Redis reads" example:"200ms"`
+	WriteTimeout time.Duration `mapstructure:"REDIS_WRITE_TIMEOUT" validate:"min=0s" desc:"Timeout of Redis writes" example:"200ms"`
+	IdleTimeout  time.Duration `mapstructure:"REDIS_IDLE_TIMEOUT" validate:"min=0s" desc:"Idle duration after which Redis connections are closed" example:"200ms"`
+	DB           int           `mapstructure:"REDIS_DB" validate:"min=0" desc:"Redis database number"`
+	Port         int           `mapstructure:"REDIS_PORT" validate:"required,min=1,max=65535" desc:"Redis port" example:"6379"`
+	PoolSize     int           `mapstructure:"REDIS_POOL_SIZE" validate:"min=0" desc:"Maximum number of Redis connections, 0 uses the client default" example:"10"`
 }
 
 var RedisCache CacheConfig
-
-func initCacheConfig() {
-	RedisCache = CacheConfig{
-		Host:         viper.GetString("REDIS_HOST"),
-		Username:     viper.GetString("REDIS_USERNAME"),
-		Password:     viper.GetString("REDIS_PASSWORD"),
-		DB:           viper.GetInt("REDIS_DB"),
-		Port:         viper.GetInt("REDIS_PORT"),
-		PoolSize:     viper.GetInt("REDIS_POOL_SIZE"),
-		DialTimeout:  time.Duration(viper.GetDuration("REDIS_DIAL_TIMEOUT").Milliseconds()),
-		ReadTimeout:  time.Duration(viper.GetDuration("REDIS_READ_TIMEOUT").Milliseconds()),
-		WriteTimeout: time.Duration(viper.GetDuration("REDIS_WRITE_TIMEOUT").Milliseconds()),
-		IdleTimeout:  time.Duration(viper.GetDuration("REDIS_IDLE_TIMEOUT").Milliseconds()),
-	}
-}
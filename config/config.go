@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/spf13/viper"
@@ -8,39 +9,92 @@ import (
 
 var ConfigLoadedForTest bool
 
-func Init() {
-	if os.Getenv("ENVIRONMENT") == "test" {
-		viper.SetConfigName("test.application")
-	} else {
-		viper.SetConfigName("application")
+// Config is the whole application configuration, Init publishes each section as a package variable and reloads
+// are only visible through Current
+type Config struct {
+	App        AppConfig
+	Server     ServerConfig
+	Database   DatabaseConfig
+	Logger     LoggerConfig
+	RedisCache CacheConfig
+	Metrics    MetricsConfig
+	Tracing    TracingConfig
+	Redact     RedactConfig
+	Startup    StartupConfig
+	Migration  MigrationConfig
+}
+
+// Init loads and validates the configuration and publishes it, nothing is published when it is invalid
+func Init() error {
+	cfg, err := Load()
+	if err != nil {
+		return err
 	}
 
-	viper.SetConfigType("yml")
-	viper.AddConfigPath("./")
-	viper.AddConfigPath("./../")
-	viper.AddConfigPath("./../../")
-	viper.AddConfigPath("./../../../")
-	// For docker only
-	viper.AddConfigPath("/app")
+	reloadState.mu.Lock()
+	defer reloadState.mu.Unlock()
 
-	err := viper.ReadInConfig()
-	if err != nil {
-		return
+	publish(cfg)
+	return nil
+}
+
+// Load reads the layered configuration files and the environment into a Config and validates it.
+// Every invalid key is reported in a single *ValidationError.
+func Load() (*Config, error) {
+	if err := readConfigFiles(); err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	if errs := check(cfg); len(errs) > 0 {
+		return cfg, &ValidationError{Errors: errs}
+	}
+	return cfg, nil
+}
+
+// LoadFile reads the file at path alone into a Config and validates it, without overlays or environment variables,
+// so two files can be compared as they are written
+func LoadFile(path string) (*Config, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("read config file %s: %w", path, err)
 	}
 
-	viper.AutomaticEnv()
+	cfg := &Config{}
+	if errs := checkFrom(v, cfg); len(errs) > 0 {
+		return cfg, &ValidationError{Errors: errs}
+	}
+	return cfg, nil
+}
 
-	initAppConfig()
-	initServerConfig()
-	initDatabaseConfig()
-	initLoggerConfig()
-	initCacheConfig()
+// check decodes and validates target, keys that failed to decode are not validated again
+func check(target any) []FieldError {
+	return checkFrom(viper.GetViper(), target)
+}
+
+// checkFrom is check reading the values from src instead of the global viper
+func checkFrom(src *viper.Viper, target any) []FieldError {
+	errs := decodeFrom(src, target)
+	failed := make(map[string]bool, len(errs))
+	for _, err := range errs {
+		failed[err.Key] = true
+	}
+
+	for _, err := range validate(target) {
+		if !failed[err.Key] {
+			errs = append(errs, err)
+		}
+	}
+	return errs
 }
 
 func InitForTest() {
 	_ = os.Setenv("ENVIRONMENT", "test")
 	if !ConfigLoadedForTest {
-		Init()
+		if err := Init(); err != nil {
+			panic(err)
+		}
 	}
 	ConfigLoadedForTest = true
 }
@@ -2,51 +2,126 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"net/url"
+	"strconv"
 	"time"
-
-	"github.com/spf13/viper"
 )
 
 type DatabaseConfig struct {
-	DriverName            string
-	Name                  string
-	Host                  string
-	User                  string
-	Password              string
-	Port                  int
-	MaxPoolSize           int
-	ReadTimeout           time.Duration
-	WriteTimeout          time.Duration
-	ConnectionMaxOpen     int
-	ConnectionMaxIdle     int
-	ConnectionMaxLifeTime time.Duration
+	DriverName            string        `mapstructure:"DB_DRIVER" validate:"required,oneof=postgres" desc:"Database driver" example:"postgres"`
+	Name                  string        `mapstructure:"DB_NAME" validate:"required" desc:"Database name" example:"go_skeleton"`
+	Host                  string        `mapstructure:"DB_HOST" validate:"required,hostname" desc:"Database host" example:"postgres-db"`
+	User                  string        `mapstructure:"DB_USER" validate:"required" desc:"Database user" example:"postgres"`
+	Password              string        `mapstructure:"DB_PASSWORD" secret:"true" desc:"Database password" example:"postgres"`
+	Port                  int           `mapstructure:"DB_PORT" validate:"required,min=1,max=65535" desc:"Database port" example:"5432"`
+	MaxPoolSize           int           `mapstructure:"DB_POOL_SIZE" validate:"min=0" desc:"Maximum number of open connections when DB_CONNECTION_MAX_OPEN is not set" example:"20"`
+	ReadTimeout           time.Duration `mapstructure:"DB_READ_TIMEOUT_MS" validate:"min=0s" desc:"Maximum wait for each read from the database socket, keep it above DB_STATEMENT_TIMEOUT_MS, 0 disables it" example:"30s"`
+	WriteTimeout          time.Duration `mapstructure:"DB_WRITE_TIMEOUT_MS" validate:"min=0s" desc:"Maximum wait for each write to the database socket, 0 disables it" example:"10s"`
+	ConnectionMaxOpen     int           `mapstructure:"DB_CONNECTION_MAX_OPEN" validate:"min=0" desc:"Maximum number of open connections, 0 falls back to DB_POOL_SIZE"`
+	ConnectionMaxIdle     int           `mapstructure:"DB_CONNECTION_MAX_IDLE" default:"2" validate:"min=0" desc:"Maximum number of idle connections kept in the pool" example:"10"`
+	ConnectionMaxLifeTime time.Duration `mapstructure:"DB_CONNECTION_MAX_LIFETIME_MINUTE" validate:"min=0s" desc:"Maximum lifetime of a database connection" example:"20m"`
+	ConnectionMaxIdleTime time.Duration `mapstructure:"DB_CONNECTION_MAX_IDLE_TIME_MINUTE" validate:"min=0s" desc:"Close connections idle for longer than this, 0 keeps them" example:"5m"`
+	StatementTimeout      time.Duration `mapstructure:"DB_STATEMENT_TIMEOUT_MS" validate:"min=0s" desc:"Session statement_timeout, 0 uses the server setting" example:"15s"`
+	LockTimeout           time.Duration `mapstructure:"DB_LOCK_TIMEOUT_MS" validate:"min=0s" desc:"Session lock_timeout, 0 uses the server setting" example:"5s"`
+	SlowQueryThreshold    time.Duration `mapstructure:"DB_SLOW_QUERY_THRESHOLD_MS" default:"200ms" validate:"min=0s" reload:"live" desc:"Queries slower than this are logged, 0 disables the log"`
+	ApplicationName       string        `mapstructure:"DB_APPLICATION_NAME" default:"go-skeleton" desc:"application_name reported in pg_stat_activity"`
+	TLS                   DatabaseTLSConfig
+	Replicas              DatabaseReplicaConfig
+}
+
+// DatabaseTLSConfig holds the libpq TLS settings, file paths may differ per environment
+type DatabaseTLSConfig struct {
+	SSLMode  string `mapstructure:"DB_SSL_MODE" default:"disable" validate:"oneof=disable require verify-ca verify-full" desc:"TLS mode of the connection"`
+	RootCert string `mapstructure:"DB_SSL_ROOT_CERT" desc:"Path of the CA certificate that verifies the server, for verify-ca and verify-full"`
+	Cert     string `mapstructure:"DB_SSL_CERT" desc:"Path of the client certificate"`
+	Key      string `mapstructure:"DB_SSL_KEY" desc:"Path of the private key of the client certificate"`
+}
+
+// DatabaseReplicaConfig lists the read replicas, they share the credentials and settings of the primary
+type DatabaseReplicaConfig struct {
+	Hosts         []string      `mapstructure:"DB_REPLICA_HOSTS" validate:"hostport" desc:"Comma separated host:port of the read replicas, empty sends reads to the primary"`
+	Strategy      string        `mapstructure:"DB_REPLICA_STRATEGY" default:"round-robin" validate:"oneof=round-robin least-connections" desc:"How reads are spread over the healthy replicas"`
+	CheckInterval time.Duration `mapstructure:"DB_REPLICA_CHECK_INTERVAL_MS" default:"5s" validate:"min=0s" desc:"Interval of the replica health checks, unhealthy replicas receive no reads"`
 }
 
 var Database DatabaseConfig
 
-func initDatabaseConfig() {
-	Database = DatabaseConfig{
-		DriverName:            viper.GetString("DB_DRIVER"),
-		Name:                  viper.GetString("DB_NAME"),
-		Host:                  viper.GetString("DB_HOST"),
-		User:                  viper.GetString("DB_USER"),
-		Password:              viper.GetString("DB_PASSWORD"),
-		Port:                  viper.GetInt("DB_PORT"),
-		MaxPoolSize:           viper.GetInt("DB_POOL_SIZE"),
-		ReadTimeout:           time.Duration(viper.GetInt("DB_READ_TIMEOUT_MS")) * time.Millisecond,
-		WriteTimeout:          time.Duration(viper.GetInt("DB_WRITE_TIMEOUT_MS")) * time.Millisecond,
-		ConnectionMaxLifeTime: time.Duration(viper.GetInt("DB_CONNECTION_MAX_LIFETIME_MINUTE")) * time.Minute,
+// MaxOpenConnections returns ConnectionMaxOpen, or MaxPoolSize when it is not set
+func (dc DatabaseConfig) MaxOpenConnections() int {
+	if dc.ConnectionMaxOpen > 0 {
+		return dc.ConnectionMaxOpen
+	}
+	return dc.MaxPoolSize
+}
+
+// Replica returns the configuration of the replica at hostPort
+func (dc DatabaseConfig) Replica(hostPort string) (DatabaseConfig, error) {
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return DatabaseConfig{}, err
+	}
+	dc.Host = host
+	if dc.Port, err = strconv.Atoi(port); err != nil {
+		return DatabaseConfig{}, fmt.Errorf("invalid port in %q", hostPort)
+	}
+	return dc, nil
+}
+
+// Migration returns the configuration of the migration connection, without the statement, lock and socket timeouts
+// meant for application queries as migrations may run far longer
+func (dc DatabaseConfig) Migration() DatabaseConfig {
+	dc.StatementTimeout = 0
+	dc.LockTimeout = 0
+	dc.ReadTimeout = 0
+	dc.WriteTimeout = 0
+	return dc
+}
+
+// validateFields rejects a socket read timeout that would cut off queries before Postgres cancels them
+func (dc DatabaseConfig) validateFields() []FieldError {
+	if dc.ReadTimeout > 0 && dc.StatementTimeout > 0 && dc.ReadTimeout <= dc.StatementTimeout {
+		return []FieldError{{
+			Key:     "DB_READ_TIMEOUT_MS",
+			Message: fmt.Sprintf("%s must be greater than DB_STATEMENT_TIMEOUT_MS (%s) or 0", dc.ReadTimeout, dc.StatementTimeout),
+		}}
 	}
+	return nil
 }
 
 func (dc DatabaseConfig) ConnectionURL() string {
-	return fmt.Sprintf("%s://%s:%s@%s:%d/%s?sslmode=disable",
+	sslMode := dc.TLS.SSLMode
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+
+	params := url.Values{}
+	params.Set("sslmode", sslMode)
+	setParam(params, "sslrootcert", dc.TLS.RootCert)
+	setParam(params, "sslcert", dc.TLS.Cert)
+	setParam(params, "sslkey", dc.TLS.Key)
+	setParam(params, "application_name", dc.ApplicationName)
+	// Unknown parameters are sent by lib/pq as session settings
+	if dc.StatementTimeout > 0 {
+		params.Set("statement_timeout", strconv.FormatInt(dc.StatementTimeout.Milliseconds(), 10))
+	}
+	if dc.LockTimeout > 0 {
+		params.Set("lock_timeout", strconv.FormatInt(dc.LockTimeout.Milliseconds(), 10))
+	}
+
+	return fmt.Sprintf("%s://%s:%s@%s:%d/%s?%s",
 		dc.DriverName,
 		dc.User,
 		url.QueryEscape(dc.Password),
 		dc.Host,
 		dc.Port,
 		dc.Name,
+		params.Encode(),
 	)
 }
+
+func setParam(params url.Values, key, value string) {
+	if value != "" {
+		params.Set(key, value)
+	}
+}
@@ -0,0 +1,205 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// decode fills every field of target tagged with a mapstructure key from viper, falling back to the default tag.
+// An empty value counts as unset. Nested structs without a key are decoded recursively.
+func decode(target any) []FieldError {
+	return decodeFrom(viper.GetViper(), target)
+}
+
+// decodeFrom is decode reading the values from src instead of the global viper
+func decodeFrom(src *viper.Viper, target any) []FieldError {
+	return decodeStruct(src, reflect.ValueOf(target).Elem())
+}
+
+func decodeStruct(src *viper.Viper, v reflect.Value) []FieldError {
+	var errs []FieldError
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+
+		key := fieldKey(field)
+		if key == "" {
+			if field.Type.Kind() == reflect.Struct {
+				errs = append(errs, decodeStruct(src, value)...)
+			}
+			continue
+		}
+
+		value.Set(reflect.Zero(field.Type))
+		raw, err := resolveReference(lookup(src, key, field.Tag.Get("default")))
+		if err != nil {
+			errs = append(errs, FieldError{Key: key, Message: err.Error()})
+			continue
+		}
+		if raw == "" {
+			continue
+		}
+
+		if err := setValue(value, key, raw); err != nil {
+			errs = append(errs, FieldError{Key: key, Message: err.Error()})
+		}
+	}
+	return errs
+}
+
+// resolveReference replaces a "file://<path>" or "env:<NAME>" reference with the value it points to,
+// so secrets mounted by Docker or Kubernetes stay out of the YAML files
+func resolveReference(raw string) (string, error) {
+	switch {
+	case strings.HasPrefix(raw, "file://"):
+		path := strings.TrimPrefix(raw, "file://")
+		content, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return "", fmt.Errorf("read secret file: %w", err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	case strings.HasPrefix(raw, "env:"):
+		name := strings.TrimPrefix(raw, "env:")
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("referenced environment variable %s is not set", name)
+		}
+		return value, nil
+	default:
+		return raw, nil
+	}
+}
+
+// fieldKey returns the configuration key of a struct field, empty when the field is not bound to a key
+func fieldKey(field reflect.StructField) string {
+	key, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+	if key == "-" {
+		return ""
+	}
+	return key
+}
+
+// lookup returns the raw value of key in src, which holds the files and the environment, or defaultValue
+func lookup(src *viper.Viper, key, defaultValue string) string {
+	if value := rawValue(src, key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// rawValue returns the value of key in v as a string, YAML lists are joined with commas
+func rawValue(v *viper.Viper, key string) string {
+	if values, ok := v.Get(key).([]any); ok {
+		items := make([]string, 0, len(values))
+		for _, item := range values {
+			items = append(items, fmt.Sprint(item))
+		}
+		return strings.Join(items, ",")
+	}
+	return strings.TrimSpace(v.GetString(key))
+}
+
+func setValue(value reflect.Value, key, raw string) error {
+	if value.Type() == durationType {
+		d, err := parseDuration(key, raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(d))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a valid boolean", raw)
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not a valid integer", raw)
+		}
+		value.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, value.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not a valid number", raw)
+		}
+		value.SetFloat(f)
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", value.Type())
+		}
+		value.Set(reflect.ValueOf(splitList(raw)))
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+	return nil
+}
+
+// parseDuration accepts a Go duration string such as "2s" or "150ms", or a legacy integer in the unit named by the
+// key suffix (_MS, _SECONDS, _MINUTE, _HOUR), milliseconds when the key has none
+func parseDuration(key, raw string) (time.Duration, error) {
+	if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return time.Duration(n) * durationUnit(key), nil
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid duration, use an integer in %s or a duration such as \"2s\"", raw, unitName(durationUnit(key)))
+	}
+	return d, nil
+}
+
+func durationUnit(key string) time.Duration {
+	switch {
+	case strings.HasSuffix(key, "_MS"):
+		return time.Millisecond
+	case strings.HasSuffix(key, "_SECONDS"), strings.HasSuffix(key, "_SECOND"):
+		return time.Second
+	case strings.HasSuffix(key, "_MINUTES"), strings.HasSuffix(key, "_MINUTE"):
+		return time.Minute
+	case strings.HasSuffix(key, "_HOURS"), strings.HasSuffix(key, "_HOUR"):
+		return time.Hour
+	default:
+		return time.Millisecond
+	}
+}
+
+func unitName(unit time.Duration) string {
+	switch unit {
+	case time.Second:
+		return "seconds"
+	case time.Minute:
+		return "minutes"
+	case time.Hour:
+		return "hours"
+	default:
+		return "milliseconds"
+	}
+}
+
+// splitList splits a comma separated value, dropping empty items
+func splitList(raw string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
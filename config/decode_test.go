@@ -0,0 +1,66 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	viper.Reset()
+	viper.Set("SERVER_PORT", "8081")
+	viper.Set("GRPC_PORT", 8082)
+	viper.Set("READ_TIMEOUT_MS", "2000")
+	viper.Set("SHUTDOWN_DELAY_MS", 500)
+
+	var server ServerConfig
+	errs := decode(&server)
+
+	assert.Empty(t, errs)
+	assert.Equal(t, 8081, server.Port)
+	assert.Equal(t, 8082, server.GRPCPort)
+	assert.Equal(t, 2*time.Second, server.ReadTimeout)
+	assert.Equal(t, 500*time.Millisecond, server.ShutdownDelay)
+	assert.Equal(t, "release", server.GinMode)
+}
+
+func TestDecode_EnvironmentOverridesFile(t *testing.T) {
+	viper.Reset()
+	viper.AutomaticEnv()
+	viper.Set("METRICS_PATH", "/metrics")
+	t.Setenv("METRICS_ENABLED", "false")
+
+	var metrics MetricsConfig
+	assert.Empty(t, decode(&metrics))
+	assert.False(t, metrics.Enabled)
+	assert.Equal(t, "/metrics", metrics.Path)
+}
+
+func TestDecode_ListsAndNestedStructs(t *testing.T) {
+	viper.Reset()
+	viper.Set("DOCS_PATH", "./docs")
+	viper.Set("APP_DEBUG_ALLOWED_CIDRS", []any{"10.0.0.0/8", "127.0.0.1"})
+
+	var app AppConfig
+	assert.Empty(t, decode(&app))
+	assert.Equal(t, "./docs", app.DocsPath)
+	assert.Equal(t, []string{"10.0.0.0/8", "127.0.0.1"}, app.Debug.AllowedCIDRs)
+}
+
+func TestDecode_ReportsEveryInvalidValue(t *testing.T) {
+	viper.Reset()
+	viper.Set("SERVER_PORT", "http")
+	viper.Set("READ_TIMEOUT_MS", "soon")
+	viper.Set("GRPC_PORT", "8082")
+
+	var server ServerConfig
+	errs := decode(&server)
+
+	assert.Equal(t, []FieldError{
+		{Key: "SERVER_PORT", Message: `"http" is not a valid integer`},
+		{Key: "READ_TIMEOUT_MS", Message: `"soon" is not a valid duration, use an integer in milliseconds or a duration such as "2s"`},
+	}, errs)
+	assert.Equal(t, 8082, server.GRPCPort)
+}
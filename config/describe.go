@@ -0,0 +1,163 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// SourceEnv marks a value read from the environment variable named like the key
+	SourceEnv = "env"
+	// SourceDefault marks a value taken from the default tag of the field
+	SourceDefault = "default"
+	// SourceUnset marks a key without any value
+	SourceUnset = "unset"
+
+	maskedValue = "******"
+)
+
+// Entry is the effective value of one key and where it came from
+type Entry struct {
+	Section string `json:"section"`
+	Key     string `json:"key"`
+	Value   any    `json:"value"`
+	// Source is SourceEnv, SourceDefault, SourceUnset or the path of the file setting the key
+	Source string `json:"source"`
+	// Reference is the file:// or env: reference the value was resolved from
+	Reference string `json:"reference,omitempty"`
+	// actual is the unmasked value so secrets are compared by Diff without being printed
+	actual any
+}
+
+// DiffEntry is a key whose effective value differs between two configurations
+type DiffEntry struct {
+	Section     string `json:"section"`
+	Key         string `json:"key"`
+	Value       any    `json:"value"`
+	Source      string `json:"source"`
+	OtherValue  any    `json:"other_value"`
+	OtherSource string `json:"other_source"`
+}
+
+// Describe lists every key of cfg with its source, secrets are masked.
+// Sources refer to the files of the last load, so cfg must come from the last call to Load.
+func Describe(cfg *Config) []Entry {
+	return describe(cfg, Files(), true)
+}
+
+// DescribeFile is Describe for a Config returned by LoadFile(path), whose values never come from the environment
+func DescribeFile(cfg *Config, path string) []Entry {
+	return describe(cfg, []string{path}, false)
+}
+
+func describe(cfg *Config, files []string, env bool) []Entry {
+	layers := make([]*viper.Viper, 0)
+	for _, file := range files {
+		v := viper.New()
+		v.SetConfigFile(file)
+		if err := v.ReadInConfig(); err == nil {
+			layers = append(layers, v)
+		} else {
+			layers = append(layers, viper.New())
+		}
+	}
+
+	var entries []Entry
+	v := reflect.ValueOf(cfg).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		entries = describeStruct(v.Type().Field(i).Name, v.Field(i), files, layers, env, entries)
+	}
+	return entries
+}
+
+func describeStruct(section string, v reflect.Value, files []string, layers []*viper.Viper, env bool, entries []Entry) []Entry {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		key := fieldKey(field)
+		if key == "" {
+			if field.Type.Kind() == reflect.Struct {
+				entries = describeStruct(section, v.Field(i), files, layers, env, entries)
+			}
+			continue
+		}
+
+		source, raw := sourceOf(key, field.Tag.Get("default"), files, layers, env)
+		entry := Entry{
+			Section: section,
+			Key:     key,
+			Value:   displayValue(field, v.Field(i)),
+			Source:  source,
+			actual:  v.Field(i).Interface(),
+		}
+		if strings.HasPrefix(raw, "file://") || strings.HasPrefix(raw, "env:") {
+			entry.Reference = raw
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// sourceOf returns where the value of key comes from and its raw value, following the precedence of decode.
+// The environment is only looked at when env is true.
+func sourceOf(key, defaultValue string, files []string, layers []*viper.Viper, env bool) (string, string) {
+	if value, ok := os.LookupEnv(key); env && ok && strings.TrimSpace(value) != "" {
+		return SourceEnv, strings.TrimSpace(value)
+	}
+
+	for i := len(layers) - 1; i >= 0; i-- {
+		if value := rawValue(layers[i], key); value != "" {
+			return files[i], value
+		}
+	}
+
+	if defaultValue != "" {
+		return SourceDefault, defaultValue
+	}
+	return SourceUnset, ""
+}
+
+// displayValue renders durations as Go durations and masks fields tagged secret:"true"
+func displayValue(field reflect.StructField, value reflect.Value) any {
+	if field.Tag.Get("secret") == "true" {
+		if value.IsZero() {
+			return ""
+		}
+		return maskedValue
+	}
+
+	if value.Type() == durationType {
+		return fmt.Sprint(value.Interface())
+	}
+	return value.Interface()
+}
+
+// Diff returns the keys whose effective value differs between entries and other, in the order of entries
+func Diff(entries, other []Entry) []DiffEntry {
+	others := make(map[string]Entry, len(other))
+	for _, entry := range other {
+		others[entry.Key] = entry
+	}
+
+	var diff []DiffEntry
+	for _, entry := range entries {
+		o := others[entry.Key]
+		if reflect.DeepEqual(entry.actual, o.actual) {
+			continue
+		}
+		diff = append(diff, DiffEntry{
+			Section:     entry.Section,
+			Key:         entry.Key,
+			Value:       entry.Value,
+			Source:      entry.Source,
+			OtherValue:  o.Value,
+			OtherSource: o.Source,
+		})
+	}
+	return diff
+}
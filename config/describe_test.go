@@ -0,0 +1,116 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func findEntry(entries []Entry, key string) Entry {
+	for _, entry := range entries {
+		if entry.Key == key {
+			return entry
+		}
+	}
+	return Entry{}
+}
+
+func TestDescribe_Sources(t *testing.T) {
+	base := writeLayers(t, map[string]string{
+		"application.staging.yml": "SERVER_PORT: 9000\n",
+	})
+	overlay := filepath.Join(filepath.Dir(base), "application.staging.yml")
+	content, err := os.ReadFile(base)
+	assert.NoError(t, err)
+	content = regexp.MustCompile(`(?m)^METRICS_PATH:.*$`).ReplaceAll(content, nil)
+	assert.NoError(t, os.WriteFile(base, content, 0o600))
+	t.Setenv("ENVIRONMENT", "staging")
+	t.Setenv("GRPC_PORT", "2000")
+	t.Setenv("APP_DEBUG_TOKEN", "")
+
+	cfg, err := Load()
+	assert.NoError(t, err)
+
+	entries := Describe(cfg)
+
+	assert.Equal(t, Entry{Section: "Server", Key: "SERVER_PORT", Value: 9000, Source: overlay, actual: 9000}, findEntry(entries, "SERVER_PORT"))
+	assert.Equal(t, SourceEnv, findEntry(entries, "GRPC_PORT").Source)
+	assert.Equal(t, filepath.Clean(base), findEntry(entries, "DB_HOST").Source)
+	assert.Equal(t, SourceDefault, findEntry(entries, "METRICS_PATH").Source)
+	assert.Equal(t, SourceUnset, findEntry(entries, "APP_DEBUG_TOKEN").Source)
+	assert.Equal(t, "5s", findEntry(entries, "READ_TIMEOUT_MS").Value)
+}
+
+func TestDescribe_MasksSecrets(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "db_password")
+	assert.NoError(t, os.WriteFile(secret, []byte("s3cr3t\n"), 0o600))
+	writeLayers(t, map[string]string{
+		"application.staging.yml": "DB_PASSWORD: \"file://" + secret + "\"\n",
+	})
+	t.Setenv("ENVIRONMENT", "staging")
+
+	cfg, err := Load()
+	assert.NoError(t, err)
+
+	entry := findEntry(Describe(cfg), "DB_PASSWORD")
+
+	assert.Equal(t, "******", entry.Value)
+	assert.Equal(t, "file://"+secret, entry.Reference)
+	assert.Equal(t, "s3cr3t", cfg.Database.Password)
+}
+
+func TestDiff(t *testing.T) {
+	base := writeLayers(t, nil)
+	cfg, err := Load()
+	assert.NoError(t, err)
+	entries := Describe(cfg)
+
+	content, err := os.ReadFile(base)
+	assert.NoError(t, err)
+	other := filepath.Join(t.TempDir(), "other.yml")
+	changed := strings.NewReplacer("SERVER_PORT: 1996", "SERVER_PORT: 9000", "DB_PASSWORD: postgres", "DB_PASSWORD: changed").
+		Replace(string(content))
+	assert.NoError(t, os.WriteFile(other, []byte(changed), 0o600))
+	otherCfg, err := LoadFile(other)
+	assert.NoError(t, err)
+
+	diff := Diff(entries, DescribeFile(otherCfg, other))
+
+	if assert.Len(t, diff, 2) {
+		assert.Equal(t, "SERVER_PORT", diff[0].Key)
+		assert.Equal(t, 9000, diff[0].OtherValue)
+		assert.Equal(t, other, diff[0].OtherSource)
+		assert.Equal(t, "DB_PASSWORD", diff[1].Key)
+		assert.Equal(t, "******", diff[1].OtherValue)
+	}
+}
+
+func TestDiff_OtherFileIgnoresEnvironment(t *testing.T) {
+	base := writeLayers(t, nil)
+	t.Setenv("LOG_LEVEL", "error")
+	cfg, err := Load()
+	assert.NoError(t, err)
+	entries := Describe(cfg)
+
+	content, err := os.ReadFile(base)
+	assert.NoError(t, err)
+	other := filepath.Join(t.TempDir(), "other.yml")
+	changed := strings.Replace(string(content), `LOG_LEVEL: "debug"`, `LOG_LEVEL: "warn"`, 1)
+	assert.NoError(t, os.WriteFile(other, []byte(changed), 0o600))
+	otherCfg, err := LoadFile(other)
+	assert.NoError(t, err)
+
+	diff := Diff(entries, DescribeFile(otherCfg, other))
+
+	if assert.Len(t, diff, 1) {
+		assert.Equal(t, DiffEntry{
+			Section: "Logger", Key: "LOG_LEVEL",
+			Value: "error", Source: SourceEnv,
+			OtherValue: "warn", OtherSource: other,
+		}, diff[0])
+	}
+}
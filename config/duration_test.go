@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// restoreGlobals puts back the config sections the duration tests decode into when the test ends
+func restoreGlobals(t *testing.T) {
+	server, cache, database, startup, migration := Server, RedisCache, Database, Startup, Migration
+	t.Cleanup(func() {
+		Server, RedisCache, Database, Startup, Migration = server, cache, database, startup, migration
+	})
+}
+
+func TestDurationFields(t *testing.T) {
+	restoreGlobals(t)
+
+	tests := []struct {
+		key      string
+		value    any
+		expected time.Duration
+		field    func() time.Duration
+	}{
+		// ServerConfig
+		{"READ_TIMEOUT_MS", 2000, 2 * time.Second, func() time.Duration { return Server.ReadTimeout }},
+		{"READ_TIMEOUT_MS", "150ms", 150 * time.Millisecond, func() time.Duration { return Server.ReadTimeout }},
+		{"WRITE_TIMEOUT_MS", "2000", 2 * time.Second, func() time.Duration { return Server.WriteTimeout }},
+		{"WRITE_TIMEOUT_MS", "2s", 2 * time.Second, func() time.Duration { return Server.WriteTimeout }},
+		{"SHUTDOWN_TIMEOUT_MS", 10000, 10 * time.Second, func() time.Duration { return Server.ShutdownTimeout }},
+		{"SHUTDOWN_TIMEOUT_MS", "1m", time.Minute, func() time.Duration { return Server.ShutdownTimeout }},
+		{"SHUTDOWN_DELAY_MS", 5000, 5 * time.Second, func() time.Duration { return Server.ShutdownDelay }},
+		{"SHUTDOWN_DELAY_MS", "500ms", 500 * time.Millisecond, func() time.Duration { return Server.ShutdownDelay }},
+		{"HEALTH_CHECK_TIMEOUT_MS", 2000, 2 * time.Second, func() time.Duration { return Server.HealthCheckTimeout }},
+		{"HEALTH_CHECK_TIMEOUT_MS", "1.5s", 1500 * time.Millisecond, func() time.Duration { return Server.HealthCheckTimeout }},
+
+		// CacheConfig, the keys have no unit suffix and integers are milliseconds
+		{"REDIS_DIAL_TIMEOUT", 200, 200 * time.Millisecond, func() time.Duration { return RedisCache.DialTimeout }},
+		{"REDIS_DIAL_TIMEOUT", "5s", 5 * time.Second, func() time.Duration { return RedisCache.DialTimeout }},
+		{"REDIS_READ_TIMEOUT", "200", 200 * time.Millisecond, func() time.Duration { return RedisCache.ReadTimeout }},
+		{"REDIS_READ_TIMEOUT", "3s", 3 * time.Second, func() time.Duration { return RedisCache.ReadTimeout }},
+		{"REDIS_WRITE_TIMEOUT", 200, 200 * time.Millisecond, func() time.Duration { return RedisCache.WriteTimeout }},
+		{"REDIS_WRITE_TIMEOUT", "250ms", 250 * time.Millisecond, func() time.Duration { return RedisCache.WriteTimeout }},
+		{"REDIS_IDLE_TIMEOUT", 300000, 5 * time.Minute, func() time.Duration { return RedisCache.IdleTimeout }},
+		{"REDIS_IDLE_TIMEOUT", "5m", 5 * time.Minute, func() time.Duration { return RedisCache.IdleTimeout }},
+
+		// DatabaseConfig
+		{"DB_READ_TIMEOUT_MS", 200, 200 * time.Millisecond, func() time.Duration { return Database.ReadTimeout }},
+		{"DB_READ_TIMEOUT_MS", "1s", time.Second, func() time.Duration { return Database.ReadTimeout }},
+		{"DB_WRITE_TIMEOUT_MS", "200", 200 * time.Millisecond, func() time.Duration { return Database.WriteTimeout }},
+		{"DB_WRITE_TIMEOUT_MS", "750ms", 750 * time.Millisecond, func() time.Duration { return Database.WriteTimeout }},
+		{"DB_CONNECTION_MAX_LIFETIME_MINUTE", 20, 20 * time.Minute, func() time.Duration { return Database.ConnectionMaxLifeTime }},
+		{"DB_CONNECTION_MAX_LIFETIME_MINUTE", "1h30m", 90 * time.Minute, func() time.Duration { return Database.ConnectionMaxLifeTime }},
+		{"DB_CONNECTION_MAX_IDLE_TIME_MINUTE", 5, 5 * time.Minute, func() time.Duration { return Database.ConnectionMaxIdleTime }},
+		{"DB_CONNECTION_MAX_IDLE_TIME_MINUTE", "90s", 90 * time.Second, func() time.Duration { return Database.ConnectionMaxIdleTime }},
+		{"DB_STATEMENT_TIMEOUT_MS", 15000, 15 * time.Second, func() time.Duration { return Database.StatementTimeout }},
+		{"DB_STATEMENT_TIMEOUT_MS", "1m", time.Minute, func() time.Duration { return Database.StatementTimeout }},
+		{"DB_LOCK_TIMEOUT_MS", "500", 500 * time.Millisecond, func() time.Duration { return Database.LockTimeout }},
+		{"DB_LOCK_TIMEOUT_MS", "2s", 2 * time.Second, func() time.Duration { return Database.LockTimeout }},
+		{"DB_SLOW_QUERY_THRESHOLD_MS", 500, 500 * time.Millisecond, func() time.Duration { return Database.SlowQueryThreshold }},
+		{"DB_SLOW_QUERY_THRESHOLD_MS", "1s", time.Second, func() time.Duration { return Database.SlowQueryThreshold }},
+		{"DB_REPLICA_CHECK_INTERVAL_MS", 5000, 5 * time.Second, func() time.Duration { return Database.Replicas.CheckInterval }},
+		{"DB_REPLICA_CHECK_INTERVAL_MS", "1m", time.Minute, func() time.Duration { return Database.Replicas.CheckInterval }},
+
+		// StartupConfig
+		{"STARTUP_RETRY_INITIAL_INTERVAL_MS", 250, 250 * time.Millisecond, func() time.Duration { return Startup.RetryInitialInterval }},
+		{"STARTUP_RETRY_INITIAL_INTERVAL_MS", "1s", time.Second, func() time.Duration { return Startup.RetryInitialInterval }},
+		{"STARTUP_RETRY_MAX_INTERVAL_MS", 5000, 5 * time.Second, func() time.Duration { return Startup.RetryMaxInterval }},
+		{"STARTUP_RETRY_MAX_INTERVAL_MS", "30s", 30 * time.Second, func() time.Duration { return Startup.RetryMaxInterval }},
+		{"STARTUP_RETRY_TIMEOUT_SECONDS", 120, 2 * time.Minute, func() time.Duration { return Startup.RetryTimeout }},
+		{"STARTUP_RETRY_TIMEOUT_SECONDS", "5m", 5 * time.Minute, func() time.Duration { return Startup.RetryTimeout }},
+
+		// MigrationConfig
+		{"DB_AUTO_MIGRATE_TIMEOUT_SECONDS", 90, 90 * time.Second, func() time.Duration { return Migration.AutoMigrateTimeout }},
+		{"DB_AUTO_MIGRATE_TIMEOUT_SECONDS", "10m", 10 * time.Minute, func() time.Duration { return Migration.AutoMigrateTimeout }},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s=%v", tt.key, tt.value), func(t *testing.T) {
+			viper.Reset()
+			viper.Set(tt.key, tt.value)
+
+			decode(&Server)
+			decode(&RedisCache)
+			decode(&Database)
+			decode(&Startup)
+			decode(&Migration)
+
+			assert.Equal(t, tt.expected, tt.field())
+		})
+	}
+
+	// Every duration field must be covered above
+	covered := make(map[string]bool)
+	for _, tt := range tests {
+		covered[tt.key] = true
+	}
+	for _, f := range Fields() {
+		if f.Type == TypeDuration {
+			assert.True(t, covered[f.Key], "duration key %s has no test case", f.Key)
+		}
+	}
+}
+
+func TestDurationFields_Invalid(t *testing.T) {
+	restoreGlobals(t)
+	viper.Reset()
+	viper.Set("DB_CONNECTION_MAX_LIFETIME_MINUTE", "twenty")
+	viper.Set("REDIS_DIAL_TIMEOUT", "5 seconds")
+
+	errs := append(decode(&Database), decode(&RedisCache)...)
+
+	assert.Equal(t, []FieldError{
+		{Key: "DB_CONNECTION_MAX_LIFETIME_MINUTE", Message: `"twenty" is not a valid duration, use an integer in minutes or a duration such as "2s"`},
+		{Key: "REDIS_DIAL_TIMEOUT", Message: `"5 seconds" is not a valid duration, use an integer in milliseconds or a duration such as "2s"`},
+	}, errs)
+}
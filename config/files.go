@@ -0,0 +1,194 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+const (
+	configName = "application"
+	configType = "yml"
+)
+
+// searchPaths are searched in order for the base file when no file is given with SetFile
+var searchPaths = []string{
+	"./",
+	"./../",
+	"./../../",
+	"./../../../",
+	// For docker only
+	"/app",
+}
+
+var fileState struct {
+	mu sync.Mutex
+	// explicit is the base file given with the --config flag
+	explicit string
+	// candidates are every layer file of the last load, present or not, so new overlays are picked up by Watch
+	candidates []string
+	// files are the layer files of the last load in the order they were applied
+	files []string
+}
+
+// SetFile makes path the base configuration file instead of searching for application.yml
+func SetFile(path string) {
+	fileState.mu.Lock()
+	defer fileState.mu.Unlock()
+
+	fileState.explicit = path
+}
+
+// Files returns the configuration files of the last load in the order they were applied
+func Files() []string {
+	fileState.mu.Lock()
+	defer fileState.mu.Unlock()
+
+	return slices.Clone(fileState.files)
+}
+
+// readConfigFiles reads the layered configuration into viper, later layers override earlier ones:
+//
+//	application.yml               base file, test.application.yml when ENVIRONMENT=test, or the --config file
+//	application.<ENVIRONMENT>.yml environment overlay next to the base file
+//	application.local.yml         local override next to the base file, skipped when ENVIRONMENT=test
+//
+// Environment variables override every file.
+func readConfigFiles() error {
+	fileState.mu.Lock()
+	defer fileState.mu.Unlock()
+
+	candidates, err := layerCandidates()
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	viper.SetConfigType(configType)
+	var files []string
+	for i, file := range candidates {
+		// Only the base file is mandatory
+		if i > 0 && !fileExists(file) {
+			continue
+		}
+
+		viper.SetConfigFile(file)
+		read := viper.MergeInConfig
+		if i == 0 {
+			read = viper.ReadInConfig
+		}
+		if err := read(); err != nil {
+			return fmt.Errorf("read config file %s: %w", file, err)
+		}
+		files = append(files, file)
+	}
+	viper.AutomaticEnv()
+
+	fileState.candidates = candidates
+	fileState.files = files
+	return nil
+}
+
+// layerCandidates returns the base file followed by the overlay files that may exist next to it
+func layerCandidates() ([]string, error) {
+	environment := os.Getenv("ENVIRONMENT")
+
+	base := fileState.explicit
+	if base == "" {
+		name := configName + "." + configType
+		if environment == "test" {
+			name = "test." + name
+		}
+
+		base = findFile(name)
+		if base == "" {
+			return nil, fmt.Errorf("%s not found in %s", name, strings.Join(searchPaths, ", "))
+		}
+	}
+
+	dir := filepath.Dir(base)
+	candidates := []string{filepath.Clean(base)}
+	if environment != "" {
+		candidates = append(candidates, filepath.Join(dir, configName+"."+environment+"."+configType))
+	}
+	if environment != "test" {
+		candidates = append(candidates, filepath.Join(dir, configName+".local."+configType))
+	}
+	return candidates, nil
+}
+
+func findFile(name string) string {
+	for _, dir := range searchPaths {
+		if path := filepath.Join(dir, name); fileExists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// Watch reloads the configuration whenever one of its layer files is written or created,
+// report receives the outcome of every reload
+func Watch(report func(Change, error)) error {
+	fileState.mu.Lock()
+	candidates := slices.Clone(fileState.candidates)
+	fileState.mu.Unlock()
+
+	if len(candidates) == 0 {
+		return errors.New("configuration is not loaded")
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	// Watch the directories so files replaced by editors or secret mounts are still followed
+	for _, dir := range layerDirs(candidates) {
+		if err := watcher.Add(dir); err != nil {
+			_ = watcher.Close()
+			return err
+		}
+	}
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if slices.Contains(candidates, filepath.Clean(event.Name)) &&
+					event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+					report(Reload())
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				report(Change{}, err)
+			}
+		}
+	}()
+	return nil
+}
+
+func layerDirs(files []string) []string {
+	var dirs []string
+	for _, file := range files {
+		if dir := filepath.Dir(file); !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
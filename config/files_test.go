@@ -0,0 +1,110 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeLayers copies test.application.yml as the base file of dir and writes the given overlays next to it
+func writeLayers(t *testing.T, overlays map[string]string) string {
+	t.Helper()
+
+	content, err := os.ReadFile("../test.application.yml")
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	base := filepath.Join(dir, "application.yml")
+	assert.NoError(t, os.WriteFile(base, content, 0o600))
+	for name, overlay := range overlays {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(overlay), 0o600))
+	}
+
+	viper.Reset()
+	SetFile(base)
+	t.Cleanup(func() { SetFile("") })
+	return base
+}
+
+func TestLoad_Layers(t *testing.T) {
+	base := writeLayers(t, map[string]string{
+		"application.staging.yml": "SERVER_PORT: 9000\nLOG_LEVEL: \"warn\"\n",
+		"application.local.yml":   "LOG_LEVEL: \"error\"\n",
+		"application.prod.yml":    "SERVER_PORT: 9999\n",
+	})
+	t.Setenv("ENVIRONMENT", "staging")
+	t.Setenv("GRPC_PORT", "2000")
+
+	cfg, err := Load()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 9000, cfg.Server.Port)
+	assert.Equal(t, "error", cfg.Logger.Level)
+	assert.Equal(t, 2000, cfg.Server.GRPCPort)
+	assert.Equal(t, "postgres-db-test", cfg.Database.Host)
+	assert.Equal(t, []string{
+		base,
+		filepath.Join(filepath.Dir(base), "application.staging.yml"),
+		filepath.Join(filepath.Dir(base), "application.local.yml"),
+	}, Files())
+}
+
+func TestLoad_TestEnvironmentSkipsLocalOverride(t *testing.T) {
+	base := writeLayers(t, map[string]string{
+		"application.local.yml": "LOG_LEVEL: \"error\"\n",
+	})
+	t.Setenv("ENVIRONMENT", "test")
+
+	cfg, err := Load()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "debug", cfg.Logger.Level)
+	assert.Equal(t, []string{base}, Files())
+}
+
+func TestLoad_InvalidOverlay(t *testing.T) {
+	writeLayers(t, map[string]string{
+		"application.local.yml": "LOG_LEVEL: [unterminated\n",
+	})
+	t.Setenv("ENVIRONMENT", "")
+
+	_, err := Load()
+
+	assert.ErrorContains(t, err, "application.local.yml")
+}
+
+func TestLoad_SecretReferences(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "db_password")
+	assert.NoError(t, os.WriteFile(secret, []byte("s3cret\n"), 0o600))
+
+	writeLayers(t, map[string]string{
+		"application.local.yml": "DB_PASSWORD: \"file://" + secret + "\"\nREDIS_PASSWORD: \"env:REDIS_SECRET\"\n",
+	})
+	t.Setenv("ENVIRONMENT", "")
+	t.Setenv("REDIS_SECRET", "r3dis")
+
+	cfg, err := Load()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", cfg.Database.Password)
+	assert.Equal(t, "r3dis", cfg.RedisCache.Password)
+}
+
+func TestLoad_MissingSecretReferences(t *testing.T) {
+	writeLayers(t, map[string]string{
+		"application.local.yml": "DB_PASSWORD: \"file:///nonexistent/db_password\"\nREDIS_PASSWORD: \"env:MISSING_REDIS_SECRET\"\n",
+	})
+	t.Setenv("ENVIRONMENT", "")
+
+	_, err := Load()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Len(t, validationErr.Errors, 2)
+	assert.Equal(t, "DB_PASSWORD", validationErr.Errors[0].Key)
+	assert.Contains(t, validationErr.Errors[0].Message, "read secret file")
+	assert.Equal(t, FieldError{Key: "REDIS_PASSWORD", Message: "referenced environment variable MISSING_REDIS_SECRET is not set"}, validationErr.Errors[1])
+}
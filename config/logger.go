@@ -1,29 +1,33 @@
 package config
 
-import "github.com/spf13/viper"
+import (
+	"strconv"
+
+	"github.com/spf13/viper"
+)
 
 type LoggerConfig struct {
-	OutputPaths       []string `mapstructure:"LOG_OUTPUT_PATHS"`
-	ErrorOutputPaths  []string `mapstructure:"LOG_ERROR_OUTPUT_PATHS"`
-	Level             string   `mapstructure:"LOG_LEVEL"`
-	Encoding          string   `mapstructure:"LOG_ENCODING"`
-	Development       bool     `mapstructure:"LOG_DEVELOPMENT"`
-	DisableCaller     bool     `mapstructure:"LOG_DISABLE_CALLER"`
-	DisableStacktrace bool     `mapstructure:"LOG_DISABLE_STACKTRACE"`
+	OutputPaths       []string `mapstructure:"LOG_OUTPUT_PATHS" default:"stdout" desc:"Comma separated log sinks: stdout, stderr or file paths"`
+	ErrorOutputPaths  []string `mapstructure:"LOG_ERROR_OUTPUT_PATHS" default:"stderr" desc:"Comma separated sinks that also receive error entries"`
+	Level             string   `mapstructure:"LOG_LEVEL" default:"info" validate:"oneof=debug info warn error dpanic panic fatal" reload:"live" desc:"Minimum log level" example:"debug"`
+	Encoding          string   `mapstructure:"LOG_ENCODING" default:"json" validate:"oneof=json console" desc:"Log encoding"`
+	Development       bool     `mapstructure:"LOG_DEVELOPMENT" desc:"Development logger: stack traces on warnings and panics on DPanic"`
+	DisableCaller     bool     `mapstructure:"LOG_DISABLE_CALLER" desc:"Omit the caller from log entries"`
+	DisableStacktrace bool     `mapstructure:"LOG_DISABLE_STACKTRACE" desc:"Omit stack traces from error entries"`
+	File              LogFileConfig
 }
 
-var Logger LoggerConfig
-
-func initLoggerConfig() {
-	Logger.Level = getStringOrDefault("LOG_LEVEL", "info")
-	Logger.Development = getBoolOrDefault("LOG_DEVELOPMENT", false)
-	Logger.DisableCaller = getBoolOrDefault("LOG_DISABLE_CALLER", false)
-	Logger.DisableStacktrace = getBoolOrDefault("LOG_DISABLE_STACKTRACE", false)
-	Logger.Encoding = getStringOrDefault("LOG_ENCODING", "json")
-	Logger.OutputPaths = getStringSliceOrDefault("LOG_OUTPUT_PATHS", []string{"stdout"})
-	Logger.ErrorOutputPaths = getStringSliceOrDefault("LOG_ERROR_OUTPUT_PATHS", []string{"stderr"})
+// LogFileConfig controls rotation of the file sinks listed in the output paths
+type LogFileConfig struct {
+	MaxSizeMB          int  `mapstructure:"LOG_FILE_MAX_SIZE_MB" default:"100" validate:"min=1" desc:"Rotate log files larger than this"`
+	MaxAgeDays         int  `mapstructure:"LOG_FILE_MAX_AGE_DAYS" validate:"min=0" desc:"Delete rotated log files older than this, 0 keeps them" example:"7"`
+	MaxBackups         int  `mapstructure:"LOG_FILE_MAX_BACKUPS" validate:"min=0" desc:"Keep at most this many rotated log files, 0 keeps all" example:"5"`
+	Compress           bool `mapstructure:"LOG_FILE_COMPRESS" desc:"Gzip rotated log files" example:"true"`
+	RotateIntervalHour int  `mapstructure:"LOG_FILE_ROTATE_INTERVAL_HOUR" validate:"min=0" desc:"Also rotate log files on a fixed interval, 0 disables it"`
 }
 
+var Logger LoggerConfig
+
 func getStringOrDefault(key, defaultValue string) string {
 	if value := viper.GetString(key); value != "" {
 		return value
@@ -38,9 +42,25 @@ func getBoolOrDefault(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
-func getStringSliceOrDefault(key string, defaultValue []string) []string {
+func getIntOrDefault(key string, defaultValue int) int {
+	if value := viper.GetString(key); value != "" {
+		if v, err := strconv.Atoi(value); err == nil {
+			return v
+		}
+	}
+	return defaultValue
+}
+
+func getFloatOrDefault(key string, defaultValue float64) float64 {
 	if value := viper.GetString(key); value != "" {
-		return []string{value}
+		return viper.GetFloat64(key)
+	}
+	return defaultValue
+}
+
+func getStringSliceOrDefault(key string, defaultValue []string) []string {
+	if values := optionalGetStringArray(key); len(values) > 0 {
+		return values
 	}
 	return defaultValue
 }
@@ -21,7 +21,7 @@ func setupLoggerConfigForTest() {
 func TestInitLoggerConfig(t *testing.T) {
 	setupLoggerConfigForTest()
 
-	initLoggerConfig()
+	assert.Empty(t, decode(&Logger))
 
 	assert.Equal(t, "debug", Logger.Level)
 	assert.True(t, Logger.Development)
@@ -35,7 +35,7 @@ func TestInitLoggerConfig(t *testing.T) {
 func TestInitLoggerConfig_WithDefaults(t *testing.T) {
 	viper.Reset() // Clear all values to test defaults
 
-	initLoggerConfig()
+	assert.Empty(t, decode(&Logger))
 
 	assert.Equal(t, "info", Logger.Level)
 	assert.False(t, Logger.Development)
@@ -89,3 +89,24 @@ func TestGetStringSliceOrDefault(t *testing.T) {
 	result = getStringSliceOrDefault("NON_EXISTING_KEY", []string{"default1", "default2"})
 	assert.Equal(t, []string{"default1", "default2"}, result)
 }
+
+func TestInitLoggerConfig_MultipleOutputPathsAndFile(t *testing.T) {
+	viper.Reset()
+	viper.Set("LOG_OUTPUT_PATHS", "stdout, /var/log/app.log")
+	viper.Set("LOG_FILE_MAX_SIZE_MB", "50")
+	viper.Set("LOG_FILE_MAX_AGE_DAYS", "7")
+	viper.Set("LOG_FILE_MAX_BACKUPS", "3")
+	viper.Set("LOG_FILE_COMPRESS", "true")
+	viper.Set("LOG_FILE_ROTATE_INTERVAL_HOUR", "24")
+
+	assert.Empty(t, decode(&Logger))
+
+	assert.Equal(t, []string{"stdout", "/var/log/app.log"}, Logger.OutputPaths)
+	assert.Equal(t, LogFileConfig{
+		MaxSizeMB:          50,
+		MaxAgeDays:         7,
+		MaxBackups:         3,
+		Compress:           true,
+		RotateIntervalHour: 24,
+	}, Logger.File)
+}
@@ -0,0 +1,8 @@
+package config
+
+type MetricsConfig struct {
+	Enabled bool   `mapstructure:"METRICS_ENABLED" default:"true" desc:"Serve Prometheus metrics"`
+	Path    string `mapstructure:"METRICS_PATH" default:"/metrics" validate:"required" desc:"Path of the Prometheus metrics endpoint"`
+}
+
+var Metrics MetricsConfig
@@ -0,0 +1,14 @@
+package config
+
+import "time"
+
+// MigrationConfig controls where the database migrations are read from and whether the server applies them
+type MigrationConfig struct {
+	// Dir replaces the migrations embedded in the binary, migrate create writes to it
+	Dir string `mapstructure:"MIGRATION_DIR" desc:"Directory of the migrations used instead of the ones embedded in the binary, migrate create writes to it (migrations when empty)"`
+	// AutoMigrate applies the pending migrations before the server starts, one instance at a time
+	AutoMigrate        bool          `mapstructure:"DB_AUTO_MIGRATE" desc:"Apply pending migrations on server start, instances wait for each other through a Postgres advisory lock"`
+	AutoMigrateTimeout time.Duration `mapstructure:"DB_AUTO_MIGRATE_TIMEOUT_SECONDS" default:"5m" validate:"min=0s" desc:"Maximum wait for the migration lock held by another instance, 0 waits without limit"`
+}
+
+var Migration MigrationConfig
@@ -0,0 +1,9 @@
+package config
+
+// RedactConfig extends the built-in field names and patterns masked in logs and debug error output
+type RedactConfig struct {
+	Fields   []string `mapstructure:"REDACT_FIELDS" reload:"live" desc:"Comma separated field names masked in logs and debug errors, on top of the built-in ones"`
+	Patterns []string `mapstructure:"REDACT_PATTERNS" validate:"regexp" reload:"live" desc:"Comma separated regular expressions masked in logs and debug errors"`
+}
+
+var Redact RedactConfig
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// Change describes the outcome of a reload
+type Change struct {
+	Old *Config
+	New *Config
+	// Keys whose new value was applied
+	Changed []string
+	// Keys without reload:"live" whose new value is ignored until the process restarts
+	RestartRequired []string
+}
+
+// Has reports whether any of keys was applied by the reload
+func (c Change) Has(keys ...string) bool {
+	for _, key := range keys {
+		if slices.Contains(c.Changed, key) {
+			return true
+		}
+	}
+	return false
+}
+
+type listener struct {
+	keys []string
+	fn   func(Change)
+}
+
+var reloadState struct {
+	mu        sync.Mutex
+	listeners []listener
+}
+
+// current is the latest published configuration, replaced as a whole so readers never see a partial reload
+var current atomic.Pointer[Config]
+
+// Current returns the latest configuration including reloaded live keys, the package variables keep the values
+// loaded by Init. The returned Config must not be modified.
+func Current() *Config {
+	if cfg := current.Load(); cfg != nil {
+		return cfg
+	}
+	return &Config{}
+}
+
+// OnChange registers fn to run after a reload applied any of keys, or any key when none are given
+func OnChange(fn func(Change), keys ...string) {
+	reloadState.mu.Lock()
+	defer reloadState.mu.Unlock()
+
+	reloadState.listeners = append(reloadState.listeners, listener{keys: keys, fn: fn})
+}
+
+// Reload re-reads the configuration, publishes the keys tagged reload:"live" through Current and notifies the listeners.
+// Nothing is applied when the new configuration is invalid.
+func Reload() (Change, error) {
+	reloadState.mu.Lock()
+	defer reloadState.mu.Unlock()
+
+	if err := readConfigFiles(); err != nil {
+		return Change{}, err
+	}
+
+	next := &Config{}
+	if errs := check(next); len(errs) > 0 {
+		return Change{}, &ValidationError{Errors: errs}
+	}
+
+	old := Current()
+	change := Change{Old: old, New: next}
+	mergeReload(reflect.ValueOf(old).Elem(), reflect.ValueOf(next).Elem(), &change)
+	if len(change.Changed) == 0 {
+		return change, nil
+	}
+
+	current.Store(next)
+	for _, l := range reloadState.listeners {
+		if len(l.keys) == 0 || change.Has(l.keys...) {
+			l.fn(change)
+		}
+	}
+	return change, nil
+}
+
+// mergeReload records the changed keys and keeps the old value of every changed key that is not live
+func mergeReload(old, next reflect.Value, change *Change) {
+	t := old.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		key := fieldKey(field)
+		if key == "" {
+			if field.Type.Kind() == reflect.Struct {
+				mergeReload(old.Field(i), next.Field(i), change)
+			}
+			continue
+		}
+
+		if reflect.DeepEqual(old.Field(i).Interface(), next.Field(i).Interface()) {
+			continue
+		}
+
+		if field.Tag.Get("reload") == "live" {
+			change.Changed = append(change.Changed, key)
+			continue
+		}
+
+		change.RestartRequired = append(change.RestartRequired, key)
+		next.Field(i).Set(old.Field(i))
+	}
+}
+
+// publish makes cfg the current configuration and sets the package variables, which only Init may do as they are
+// read without synchronization
+func publish(cfg *Config) {
+	current.Store(cfg)
+
+	App = cfg.App
+	Server = cfg.Server
+	Database = cfg.Database
+	Logger = cfg.Logger
+	RedisCache = cfg.RedisCache
+	Metrics = cfg.Metrics
+	Tracing = cfg.Tracing
+	Redact = cfg.Redact
+	Startup = cfg.Startup
+	Migration = cfg.Migration
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// loadReloadFixture loads a copy of test.application.yml with the replacements applied and returns its path
+func loadReloadFixture(t *testing.T, replacements ...string) string {
+	t.Helper()
+
+	content, err := os.ReadFile("../test.application.yml")
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "application.yml")
+	writeReloadFixture(t, path, string(content), replacements...)
+
+	viper.Reset()
+	SetFile(path)
+	t.Cleanup(func() { SetFile("") })
+	assert.NoError(t, readConfigFiles())
+
+	cfg := &Config{}
+	assert.Empty(t, check(cfg))
+	publish(cfg)
+
+	previous := reloadState.listeners
+	t.Cleanup(func() { reloadState.listeners = previous })
+	return path
+}
+
+func writeReloadFixture(t *testing.T, path, content string, replacements ...string) {
+	t.Helper()
+
+	content = strings.NewReplacer(replacements...).Replace(content)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestReload(t *testing.T) {
+	path := loadReloadFixture(t)
+	content, _ := os.ReadFile(path)
+
+	var notified []Change
+	OnChange(func(change Change) { notified = append(notified, change) }, "LOG_LEVEL")
+	OnChange(func(change Change) { t.Error("listener of an unchanged key was notified") }, "READ_TIMEOUT_MS")
+
+	writeReloadFixture(t, path, string(content),
+		`LOG_LEVEL: "debug"`, `LOG_LEVEL: "warn"`,
+		`DB_HOST: postgres-db-test`, `DB_HOST: other-db`,
+	)
+
+	change, err := Reload()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"LOG_LEVEL"}, change.Changed)
+	assert.Equal(t, []string{"DB_HOST"}, change.RestartRequired)
+	assert.Equal(t, "warn", Current().Logger.Level)
+	assert.Equal(t, "debug", Logger.Level, "package variables keep the values loaded by Init")
+	assert.Equal(t, "postgres-db-test", Current().Database.Host)
+	assert.Equal(t, "postgres-db-test", change.New.Database.Host)
+	assert.Len(t, notified, 1)
+	assert.True(t, notified[0].Has("LOG_LEVEL"))
+}
+
+func TestReload_Invalid(t *testing.T) {
+	path := loadReloadFixture(t)
+	content, _ := os.ReadFile(path)
+
+	OnChange(func(Change) { t.Error("listener notified for an invalid configuration") })
+	writeReloadFixture(t, path, string(content), `LOG_LEVEL: "debug"`, `LOG_LEVEL: "loud"`)
+
+	_, err := Reload()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "debug", Current().Logger.Level)
+}
+
+func TestReload_Unchanged(t *testing.T) {
+	loadReloadFixture(t)
+
+	OnChange(func(Change) { t.Error("listener notified without changes") })
+
+	change, err := Reload()
+
+	assert.NoError(t, err)
+	assert.Empty(t, change.Changed)
+	assert.Empty(t, change.RestartRequired)
+}
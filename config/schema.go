@@ -0,0 +1,278 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	TypeString   = "string"
+	TypeInteger  = "integer"
+	TypeNumber   = "number"
+	TypeBoolean  = "boolean"
+	TypeDuration = "duration"
+	TypeList     = "list"
+)
+
+// schemaURL is the JSON Schema referenced by the generated sample file for editors
+const schemaURL = "./docs/config.schema.json"
+
+// Field describes one configuration key from the tags of its struct field
+type Field struct {
+	Section     string
+	Key         string
+	Type        string
+	Default     string
+	Example     string
+	Required    bool
+	Secret      bool
+	Live        bool
+	Description string
+	// Rules are the validate rules other than required
+	Rules []string
+}
+
+// Fields lists every configuration key in the order of the Config struct
+func Fields() []Field {
+	var fields []Field
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		fields = describeFields(t.Field(i).Name, t.Field(i).Type, fields)
+	}
+	return fields
+}
+
+func describeFields(section string, t reflect.Type, fields []Field) []Field {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		key := fieldKey(field)
+		if key == "" {
+			if field.Type.Kind() == reflect.Struct {
+				fields = describeFields(section, field.Type, fields)
+			}
+			continue
+		}
+
+		f := Field{
+			Section:     section,
+			Key:         key,
+			Type:        typeName(field.Type),
+			Default:     field.Tag.Get("default"),
+			Example:     field.Tag.Get("example"),
+			Secret:      field.Tag.Get("secret") == "true",
+			Live:        field.Tag.Get("reload") == "live",
+			Description: field.Tag.Get("desc"),
+		}
+		for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+			switch rule {
+			case "":
+			case "required":
+				f.Required = true
+			default:
+				f.Rules = append(f.Rules, rule)
+			}
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func typeName(t reflect.Type) string {
+	if t == durationType {
+		return TypeDuration
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return TypeBoolean
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return TypeInteger
+	case reflect.Float32, reflect.Float64:
+		return TypeNumber
+	case reflect.Slice:
+		return TypeList
+	default:
+		return TypeString
+	}
+}
+
+// rule returns the argument of the validate rule name, such as "1" for min=1
+func (f Field) rule(name string) (string, bool) {
+	for _, rule := range f.Rules {
+		if value, ok := strings.CutPrefix(rule, name+"="); ok {
+			return value, true
+		}
+		if rule == name {
+			return "", true
+		}
+	}
+	return "", false
+}
+
+// JSONSchema returns a JSON Schema of the configuration files. Required keys are not enforced because overlay files
+// and the environment may provide them.
+func JSONSchema() ([]byte, error) {
+	properties := make(map[string]any)
+	for _, f := range Fields() {
+		properties[f.Key] = f.jsonSchema()
+	}
+
+	return json.MarshalIndent(map[string]any{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"title":                "go-skeleton configuration",
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}, "", "  ")
+}
+
+func (f Field) jsonSchema() map[string]any {
+	schema := map[string]any{"description": f.Description}
+
+	switch f.Type {
+	case TypeDuration:
+		// Go duration strings or integers in the unit of the key suffix
+		schema["type"] = []string{TypeString, TypeInteger}
+	case TypeList:
+		// Comma separated string or YAML list
+		schema["type"] = []string{TypeString, "array"}
+		schema["items"] = map[string]any{"type": TypeString}
+	default:
+		schema["type"] = f.Type
+	}
+
+	if f.Default != "" {
+		schema["default"] = jsonValue(f.Type, f.Default)
+	}
+	if values, ok := f.rule("oneof"); ok {
+		schema["enum"] = strings.Fields(values)
+	}
+	if f.Type == TypeInteger || f.Type == TypeNumber {
+		if min, ok := f.rule("min"); ok {
+			schema["minimum"] = jsonValue(f.Type, min)
+		}
+		if max, ok := f.rule("max"); ok {
+			schema["maximum"] = jsonValue(f.Type, max)
+		}
+	}
+	if _, ok := f.rule("hostname"); ok {
+		schema["format"] = "hostname"
+	}
+	if _, ok := f.rule("url"); ok {
+		schema["format"] = "uri"
+	}
+	if f.Secret {
+		schema["writeOnly"] = true
+	}
+	return schema
+}
+
+// jsonValue converts a tag value to the JSON type of the key, falling back to the string
+func jsonValue(typ, raw string) any {
+	switch typ {
+	case TypeInteger:
+		if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return n
+		}
+	case TypeNumber:
+		if n, err := strconv.ParseFloat(raw, 64); err == nil {
+			return n
+		}
+	case TypeBoolean:
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	}
+	return raw
+}
+
+// Markdown returns the reference of every key as one markdown table per section
+func Markdown() string {
+	var b strings.Builder
+	b.WriteString("# Configuration Reference\n\n")
+	b.WriteString("<!-- Generated by `go run main.go config schema --format markdown`, do not edit. -->\n\n")
+	b.WriteString("Every key can be set in the configuration files or as an environment variable of the same name. ")
+	b.WriteString("Durations accept Go durations such as `\"2s\"` or integers in the unit of the key suffix, milliseconds without one. ")
+	b.WriteString("Secrets may reference a file (`file:///run/secrets/name`) or another variable (`env:NAME`).\n")
+
+	section := ""
+	for _, f := range Fields() {
+		if f.Section != section {
+			section = f.Section
+			fmt.Fprintf(&b, "\n## %s\n\n", section)
+			b.WriteString("| Key | Type | Default | Required | Description |\n")
+			b.WriteString("|-----|------|---------|----------|-------------|\n")
+		}
+
+		defaultValue := ""
+		if f.Default != "" {
+			defaultValue = "`" + f.Default + "`"
+		}
+		required := ""
+		if f.Required {
+			required = "yes"
+		}
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s | %s |\n", f.Key, f.Type, defaultValue, required, f.markdownDescription())
+	}
+	return b.String()
+}
+
+func (f Field) markdownDescription() string {
+	text := f.Description + "."
+	if values, ok := f.rule("oneof"); ok {
+		text += " One of `" + strings.Join(strings.Fields(values), "`, `") + "`."
+	}
+	if f.Secret {
+		text += " Secret, masked by `config print`."
+	}
+	if f.Live {
+		text += " Applied on reload."
+	}
+	return text
+}
+
+// Sample returns an example configuration file with every key, its description and its example or default value
+func Sample() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# yaml-language-server: $schema=%s\n", schemaURL)
+	b.WriteString("# Generated by `go run main.go config schema --format sample`, see docs/CONFIGURATION.md\n")
+
+	section := ""
+	for _, f := range Fields() {
+		if f.Section != section {
+			section = f.Section
+			fmt.Fprintf(&b, "\n# %s\n", section)
+		}
+
+		value := f.Example
+		if value == "" {
+			value = f.Default
+		}
+		fmt.Fprintf(&b, "# %s\n%s: %s\n", f.Description, f.Key, sampleValue(f.Type, value))
+	}
+	return b.String()
+}
+
+func sampleValue(typ, raw string) string {
+	switch typ {
+	case TypeInteger, TypeNumber:
+		if raw == "" {
+			return "0"
+		}
+		return raw
+	case TypeBoolean:
+		if raw == "" {
+			return "false"
+		}
+		return raw
+	case TypeDuration:
+		if raw == "" {
+			return `"0s"`
+		}
+	}
+	return strconv.Quote(raw)
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+const regenerate = "run `make config-docs` to regenerate it"
+
+func TestFields(t *testing.T) {
+	for _, f := range Fields() {
+		assert.NotEmpty(t, f.Description, "%s has no desc tag", f.Key)
+	}
+}
+
+func TestSample_UpToDate(t *testing.T) {
+	content, err := os.ReadFile("../application.sample.yml")
+	assert.NoError(t, err)
+
+	assert.Equal(t, Sample(), string(content), "application.sample.yml is out of date, "+regenerate)
+}
+
+func TestMarkdown_UpToDate(t *testing.T) {
+	content, err := os.ReadFile("../docs/CONFIGURATION.md")
+	assert.NoError(t, err)
+
+	assert.Equal(t, Markdown(), string(content), "docs/CONFIGURATION.md is out of date, "+regenerate)
+}
+
+func TestJSONSchema_UpToDate(t *testing.T) {
+	schema, err := JSONSchema()
+	assert.NoError(t, err)
+	content, err := os.ReadFile("../docs/config.schema.json")
+	assert.NoError(t, err)
+
+	assert.Equal(t, string(schema)+"\n", string(content), "docs/config.schema.json is out of date, "+regenerate)
+}
+
+func TestTestConfig_KnownKeys(t *testing.T) {
+	keys := make(map[string]bool)
+	for _, f := range Fields() {
+		keys[f.Key] = true
+	}
+
+	v := viper.New()
+	v.SetConfigFile("../test.application.yml")
+	assert.NoError(t, v.ReadInConfig())
+
+	for _, key := range v.AllKeys() {
+		// viper lower cases keys read from files
+		assert.True(t, keys[strings.ToUpper(key)], "test.application.yml sets unknown key %s", key)
+	}
+}
@@ -2,22 +2,19 @@ package config
 
 import (
 	"time"
-
-	"github.com/spf13/viper"
 )
 
 type ServerConfig struct {
-	Port         int
-	ReadTimeout  time.Duration
-	WriteTimeout time.Duration
+	Port               int           `mapstructure:"SERVER_PORT" validate:"required,min=1,max=65535" desc:"HTTP port" example:"8081"`
+	ReadTimeout        time.Duration `mapstructure:"READ_TIMEOUT_MS" validate:"min=0s" reload:"live" desc:"Maximum duration for reading an HTTP request" example:"2s"`
+	WriteTimeout       time.Duration `mapstructure:"WRITE_TIMEOUT_MS" validate:"min=0s" reload:"live" desc:"Maximum duration for writing an HTTP response" example:"2s"`
+	GRPCPort           int           `mapstructure:"GRPC_PORT" validate:"required,min=1,max=65535" desc:"gRPC port" example:"8082"`
+	AdminPort          int           `mapstructure:"ADMIN_PORT" validate:"min=0,max=65535" desc:"Port serving /metrics and /admin separately, 0 serves them on SERVER_PORT"`
+	AdminToken         string        `mapstructure:"ADMIN_TOKEN" secret:"true" desc:"Bearer token of the /admin endpoints, empty disables them"`
+	GinMode            string        `mapstructure:"GIN_MODE" default:"release" validate:"oneof=debug release test" desc:"Gin mode"`
+	ShutdownTimeout    time.Duration `mapstructure:"SHUTDOWN_TIMEOUT_MS" validate:"min=0s" reload:"live" desc:"Maximum duration for draining the servers on shutdown" example:"10s"`
+	ShutdownDelay      time.Duration `mapstructure:"SHUTDOWN_DELAY_MS" validate:"min=0s" reload:"live" desc:"Delay between failing the readiness probe and stopping the servers" example:"5s"`
+	HealthCheckTimeout time.Duration `mapstructure:"HEALTH_CHECK_TIMEOUT_MS" validate:"min=0s" desc:"Timeout of each dependency check of the readiness probe" example:"2s"`
 }
 
 var Server ServerConfig
-
-func initServerConfig() {
-	Server = ServerConfig{
-		Port:         viper.GetInt("SERVER_PORT"),
-		ReadTimeout:  time.Duration(viper.GetDuration("READ_TIMEOUT_MS").Milliseconds()),
-		WriteTimeout: time.Duration(viper.GetDuration("WRITE_TIMEOUT_MS").Milliseconds()),
-	}
-}
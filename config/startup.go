@@ -0,0 +1,18 @@
+package config
+
+import (
+	"time"
+)
+
+// StartupConfig controls how long the startup waits for Postgres and Redis to become reachable
+type StartupConfig struct {
+	RetryInitialInterval time.Duration `mapstructure:"STARTUP_RETRY_INITIAL_INTERVAL_MS" default:"500ms" validate:"min=0s" desc:"Delay before the second connection attempt"`
+	RetryMaxInterval     time.Duration `mapstructure:"STARTUP_RETRY_MAX_INTERVAL_MS" default:"10s" validate:"min=0s" desc:"Upper bound of the delay between connection attempts"`
+	RetryMultiplier      float64       `mapstructure:"STARTUP_RETRY_MULTIPLIER" default:"2" validate:"min=1" desc:"Factor applied to the delay after every failed attempt"`
+	RetryJitter          float64       `mapstructure:"STARTUP_RETRY_JITTER" default:"0.2" validate:"min=0,max=1" desc:"Randomizes every delay by up to this fraction"`
+	RetryTimeout         time.Duration `mapstructure:"STARTUP_RETRY_TIMEOUT_SECONDS" default:"60s" validate:"min=0s" desc:"Total time spent connecting to each dependency, 0 tries once"`
+	// Degraded starts the servers even when a dependency stays unreachable, readiness fails until it recovers
+	Degraded bool `mapstructure:"STARTUP_DEGRADED" desc:"Start with failing readiness instead of exiting when a dependency stays unreachable"`
+}
+
+var Startup StartupConfig
@@ -0,0 +1,17 @@
+package config
+
+type TracingConfig struct {
+	Exporter     string  `mapstructure:"TRACING_EXPORTER" default:"none" validate:"oneof=none stdout otlp" desc:"OpenTelemetry span exporter"`
+	ServiceName  string  `mapstructure:"TRACING_SERVICE_NAME" default:"go-skeleton" validate:"required" desc:"Service name reported on spans"`
+	OTLPEndpoint string  `mapstructure:"TRACING_OTLP_ENDPOINT" default:"localhost:4318" validate:"hostport" desc:"OTLP/HTTP collector host:port"`
+	OTLPInsecure bool    `mapstructure:"TRACING_OTLP_INSECURE" desc:"Send spans to the collector without TLS" example:"true"`
+	SampleRatio  float64 `mapstructure:"TRACING_SAMPLE_RATIO" default:"1" validate:"min=0,max=1" desc:"Fraction of new traces that are sampled"`
+}
+
+const (
+	TracingExporterNone   = "none"
+	TracingExporterStdout = "stdout"
+	TracingExporterOTLP   = "otlp"
+)
+
+var Tracing TracingConfig
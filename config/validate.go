@@ -0,0 +1,210 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// hostnameRegexp matches RFC 1123 host names such as "postgres-db" or "cache.internal"
+var hostnameRegexp = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+
+// FieldError is a problem with a single configuration key
+type FieldError struct {
+	Key     string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Key + ": " + e.Message
+}
+
+// ValidationError lists every problem found while loading the configuration
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid configuration, %d problem(s):", len(e.Errors))
+	for _, err := range e.Errors {
+		b.WriteString("\n  - ")
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// validate checks every keyed field of target, a pointer to a struct, against the rules of its validate tag:
+//
+//	required        the value must not be empty or zero
+//	min=N, max=N    numeric bounds, durations take Go duration bounds such as "0s"
+//	oneof=a b c     the value must be one of the listed words
+//	hostname        RFC 1123 host name or IP address
+//	hostport        "host:port", for lists every item
+//	url             absolute URL
+//	cidr            CIDR or IP address, for lists every item
+//	regexp          valid regular expression, for lists every item
+//
+// Rules other than required are skipped for empty values. Sections implementing fieldsValidator are checked as a
+// whole afterwards.
+func validate(target any) []FieldError {
+	return validateStruct(reflect.ValueOf(target).Elem())
+}
+
+// fieldsValidator is implemented by sections whose keys are only valid together
+type fieldsValidator interface {
+	validateFields() []FieldError
+}
+
+func validateStruct(v reflect.Value) []FieldError {
+	var errs []FieldError
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+
+		key := fieldKey(field)
+		if key == "" {
+			if field.Type.Kind() == reflect.Struct {
+				errs = append(errs, validateStruct(value)...)
+			}
+			continue
+		}
+
+		for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+			if rule == "" {
+				continue
+			}
+			if rule != "required" && value.IsZero() {
+				continue
+			}
+			if err := checkRule(rule, value); err != nil {
+				errs = append(errs, FieldError{Key: key, Message: err.Error()})
+			}
+		}
+	}
+
+	if fv, ok := v.Interface().(fieldsValidator); ok {
+		errs = append(errs, fv.validateFields()...)
+	}
+	return errs
+}
+
+func checkRule(rule string, value reflect.Value) error {
+	name, arg, _ := strings.Cut(rule, "=")
+	switch name {
+	case "required":
+		if value.IsZero() || (value.Kind() == reflect.Slice && value.Len() == 0) {
+			return errors.New("is required")
+		}
+	case "min", "max":
+		return checkBound(name, arg, value)
+	case "oneof":
+		options := strings.Fields(arg)
+		if !slices.Contains(options, value.String()) {
+			return fmt.Errorf("%q must be one of %s", value.String(), strings.Join(options, ", "))
+		}
+	case "hostname":
+		if !validHostname(value.String()) {
+			return fmt.Errorf("%q is not a valid host name", value.String())
+		}
+	case "hostport":
+		for _, item := range stringItems(value) {
+			host, port, err := net.SplitHostPort(item)
+			if err != nil || !validHostname(host) || !validPort(port) {
+				return fmt.Errorf("%q must be host:port", item)
+			}
+		}
+	case "url":
+		u, err := url.Parse(value.String())
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("%q is not an absolute URL", value.String())
+		}
+	case "cidr":
+		for _, item := range stringItems(value) {
+			if !validCIDR(item) {
+				return fmt.Errorf("%q is not a valid CIDR or IP address", item)
+			}
+		}
+	case "regexp":
+		for _, item := range stringItems(value) {
+			if _, err := regexp.Compile(item); err != nil {
+				return fmt.Errorf("%q is not a valid regular expression", item)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown validation rule %q", rule)
+	}
+	return nil
+}
+
+func checkBound(name, arg string, value reflect.Value) error {
+	var actual, bound float64
+	var display string
+	switch {
+	case value.Type() == durationType:
+		d, err := time.ParseDuration(arg)
+		if err != nil {
+			return fmt.Errorf("invalid %s rule %q", name, arg)
+		}
+		actual, bound, display = float64(value.Int()), float64(d), time.Duration(value.Int()).String()
+	case value.CanInt():
+		b, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s rule %q", name, arg)
+		}
+		actual, bound, display = float64(value.Int()), b, strconv.FormatInt(value.Int(), 10)
+	case value.CanFloat():
+		b, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s rule %q", name, arg)
+		}
+		actual, bound, display = value.Float(), b, strconv.FormatFloat(value.Float(), 'g', -1, 64)
+	default:
+		return fmt.Errorf("%s rule is not supported for %s", name, value.Type())
+	}
+
+	if name == "min" && actual < bound {
+		return fmt.Errorf("%s must be at least %s", display, arg)
+	}
+	if name == "max" && actual > bound {
+		return fmt.Errorf("%s must be at most %s", display, arg)
+	}
+	return nil
+}
+
+func stringItems(value reflect.Value) []string {
+	if value.Kind() == reflect.Slice {
+		items, _ := value.Interface().([]string)
+		return items
+	}
+	return []string{value.String()}
+}
+
+func validHostname(host string) bool {
+	if _, err := netip.ParseAddr(host); err == nil {
+		return true
+	}
+	return len(host) <= 253 && hostnameRegexp.MatchString(host)
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n >= 1 && n <= 65535
+}
+
+func validCIDR(value string) bool {
+	if _, err := netip.ParsePrefix(value); err == nil {
+		return true
+	}
+	_, err := netip.ParseAddr(value)
+	return err == nil
+}
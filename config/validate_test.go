@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	valid := func() Config {
+		return Config{
+			App:    AppConfig{DocsPath: "./docs", Debug: DebugConfig{AllowedCIDRs: []string{"127.0.0.1/32"}}},
+			Server: ServerConfig{Port: 8081, GRPCPort: 8082, GinMode: "release", ReadTimeout: time.Second},
+			Database: DatabaseConfig{
+				DriverName: "postgres", Name: "app", Host: "postgres-db", User: "postgres", Port: 5432,
+			},
+			Logger:     LoggerConfig{Level: "info", Encoding: "json", File: LogFileConfig{MaxSizeMB: 100}},
+			RedisCache: CacheConfig{Host: "localhost", Port: 6379},
+			Metrics:    MetricsConfig{Enabled: true, Path: "/metrics"},
+			Tracing:    TracingConfig{Exporter: "none", ServiceName: "app", OTLPEndpoint: "localhost:4318", SampleRatio: 1},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(cfg *Config)
+		expected []FieldError
+	}{
+		{"valid", func(_ *Config) {}, nil},
+		{"required", func(cfg *Config) { cfg.Database.Host = "" }, []FieldError{{"DB_HOST", "is required"}}},
+		{"min", func(cfg *Config) { cfg.Server.ReadTimeout = -time.Second }, []FieldError{{"READ_TIMEOUT_MS", "-1s must be at least 0s"}}},
+		{"max", func(cfg *Config) { cfg.RedisCache.Port = 70000 }, []FieldError{{"REDIS_PORT", "70000 must be at most 65535"}}},
+		{"float range", func(cfg *Config) { cfg.Tracing.SampleRatio = 1.5 }, []FieldError{{"TRACING_SAMPLE_RATIO", "1.5 must be at most 1"}}},
+		{"oneof", func(cfg *Config) { cfg.Logger.Encoding = "xml" }, []FieldError{{"LOG_ENCODING", `"xml" must be one of json, console`}}},
+		{"hostname", func(cfg *Config) { cfg.Database.Host = "db host" }, []FieldError{{"DB_HOST", `"db host" is not a valid host name`}}},
+		{"hostport", func(cfg *Config) { cfg.Tracing.OTLPEndpoint = "collector" }, []FieldError{{"TRACING_OTLP_ENDPOINT", `"collector" must be host:port`}}},
+		{"hostport list", func(cfg *Config) { cfg.Database.Replicas.Hosts = []string{"replica-1:5432", "replica-2"} }, []FieldError{{"DB_REPLICA_HOSTS", `"replica-2" must be host:port`}}},
+		{"cidr", func(cfg *Config) { cfg.App.Debug.AllowedCIDRs = []string{"10.0.0.0/8", "intranet"} }, []FieldError{{"APP_DEBUG_ALLOWED_CIDRS", `"intranet" is not a valid CIDR or IP address`}}},
+		{"regexp", func(cfg *Config) { cfg.Redact.Patterns = []string{"("} }, []FieldError{{"REDACT_PATTERNS", `"(" is not a valid regular expression`}}},
+		{
+			"read timeout below statement timeout",
+			func(cfg *Config) {
+				cfg.Database.ReadTimeout = 200 * time.Millisecond
+				cfg.Database.StatementTimeout = 15 * time.Second
+			},
+			[]FieldError{{"DB_READ_TIMEOUT_MS", "200ms must be greater than DB_STATEMENT_TIMEOUT_MS (15s) or 0"}},
+		},
+		{
+			"aggregated",
+			func(cfg *Config) {
+				cfg.App.DocsPath = ""
+				cfg.Server.GinMode = "prod"
+			},
+			[]FieldError{{"DOCS_PATH", "is required"}, {"GIN_MODE", `"prod" must be one of debug, release, test`}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(&cfg)
+			assert.Equal(t, tt.expected, validate(&cfg))
+		})
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	err := &ValidationError{Errors: []FieldError{
+		{Key: "DB_HOST", Message: "is required"},
+		{Key: "SERVER_PORT", Message: "70000 must be at most 65535"},
+	}}
+
+	assert.Equal(t, "invalid configuration, 2 problem(s):\n  - DB_HOST: is required\n  - SERVER_PORT: 70000 must be at most 65535", err.Error())
+}
+
+func TestLoad_TestConfig(t *testing.T) {
+	viper.Reset()
+	t.Setenv("ENVIRONMENT", "test")
+
+	cfg, err := Load()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1996, cfg.Server.Port)
+	assert.Equal(t, 5*time.Second, cfg.Database.ReadTimeout)
+}
+
+func TestCheck_SampleConfig(t *testing.T) {
+	viper.Reset()
+	viper.SetConfigFile("../application.sample.yml")
+	assert.NoError(t, viper.ReadInConfig())
+
+	assert.Empty(t, check(&Config{}))
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	viper.Reset()
+	t.Setenv("ENVIRONMENT", "missing")
+
+	dir := t.TempDir()
+	wd, _ := os.Getwd()
+	assert.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	_, err := Load()
+	assert.ErrorContains(t, err, "read config file")
+}
@@ -1,23 +1,54 @@
 package container
 
 import (
+	"context"
+	"go-skeleton/config"
 	"go-skeleton/pkg/cache"
 	"go-skeleton/pkg/database"
+	"go-skeleton/pkg/healthcheck"
+	"go-skeleton/pkg/metrics"
 
 	"github.com/go-redis/redis/v8"
-	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // Container holds all the dependencies for the application
 type Container struct {
-	DB    *sqlx.DB
-	Cache *redis.Client
+	DB      *database.Cluster
+	Tx      *database.Transactor
+	Cache   *redis.Client
+	Health  *healthcheck.Registry
+	Metrics prometheus.Registerer
 }
 
 // NewContainer creates a new dependency injection container
 func NewContainer() Container {
-	return Container{
-		DB:    database.DBConn,
-		Cache: cache.RedisClient,
+	c := Container{
+		DB:      database.DBCluster,
+		Cache:   cache.RedisClient,
+		Health:  newHealthRegistry(),
+		Metrics: metrics.Registry,
 	}
+
+	if c.DB != nil {
+		c.Tx = database.NewTransactor(c.DB)
+	}
+	return c
+}
+
+// newHealthRegistry registers the shared infrastructure checks, modules register their own through Container.Health
+func newHealthRegistry() *healthcheck.Registry {
+	registry := healthcheck.NewRegistry(config.Server.HealthCheckTimeout)
+
+	if db := database.DBConn; db != nil {
+		registry.Register("postgres", db.PingContext)
+	}
+
+	if client := cache.RedisClient; client != nil {
+		registry.Register("redis", func(ctx context.Context) error {
+			return client.Ping(ctx).Err()
+		})
+	}
+
+	return registry
 }
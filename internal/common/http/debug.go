@@ -0,0 +1,53 @@
+package common
+
+import (
+	"crypto/subtle"
+	"net/netip"
+	"strings"
+
+	"go-skeleton/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// debugAllowed reports whether the caller may receive debug errors: it must send the configured debug token or
+// connect from an allowed address. The peer address is used rather than X-Forwarded-For, which any client can set.
+func debugAllowed(c *gin.Context, cfg config.DebugConfig) bool {
+	if cfg.Token != "" {
+		token := c.GetHeader(HeaderAppDebugToken.String())
+		if subtle.ConstantTimeCompare([]byte(token), []byte(cfg.Token)) == 1 {
+			return true
+		}
+	}
+
+	if len(cfg.AllowedCIDRs) == 0 {
+		return false
+	}
+
+	addr, err := netip.ParseAddr(c.RemoteIP())
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	for _, allowed := range cfg.AllowedCIDRs {
+		if prefix, ok := parsePrefix(allowed); ok && prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePrefix accepts a CIDR or a single address
+func parsePrefix(value string) (netip.Prefix, bool) {
+	if strings.Contains(value, "/") {
+		prefix, err := netip.ParsePrefix(value)
+		return prefix, err == nil
+	}
+
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Prefix{}, false
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), true
+}
@@ -0,0 +1,57 @@
+package common
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-skeleton/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func debugContext(remoteAddr, token string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	c.Request.RemoteAddr = remoteAddr
+	if token != "" {
+		c.Request.Header.Set(HeaderAppDebugToken.String(), token)
+	}
+	return c
+}
+
+func TestDebugAllowed(t *testing.T) {
+	cfg := config.DebugConfig{
+		AllowedCIDRs: []string{"10.0.0.0/8", "192.168.1.5"},
+		Token:        "debug-secret",
+	}
+
+	tests := []struct {
+		name       string
+		cfg        config.DebugConfig
+		remoteAddr string
+		token      string
+		expected   bool
+	}{
+		{"disabled by default", config.DebugConfig{}, "10.1.2.3:1234", "", false},
+		{"allowed cidr", cfg, "10.1.2.3:1234", "", true},
+		{"allowed address", cfg, "192.168.1.5:1234", "", true},
+		{"other address", cfg, "203.0.113.7:1234", "", false},
+		{"valid token", cfg, "203.0.113.7:1234", "debug-secret", true},
+		{"invalid token", cfg, "203.0.113.7:1234", "guess", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, debugAllowed(debugContext(tt.remoteAddr, tt.token), tt.cfg))
+		})
+	}
+}
+
+func TestDebugAllowed_IgnoresForwardedFor(t *testing.T) {
+	c := debugContext("203.0.113.7:1234", "")
+	c.Request.Header.Set(HeaderXForwardedFor.String(), "10.1.2.3")
+
+	assert.False(t, debugAllowed(c, config.DebugConfig{AllowedCIDRs: []string{"10.0.0.0/8"}}))
+}
@@ -1,5 +1,7 @@
 package common
 
+import "go-skeleton/pkg/requestid"
+
 type Header string
 
 const (
@@ -11,7 +13,7 @@ const (
 	// header field names MUST be treated as malformed (Section 8.1.2.6).
 
 	// HeaderRequestID HTTP Header Standard
-	HeaderRequestID              Header = `x-request-id`
+	HeaderRequestID              Header = requestid.HeaderName
 	HeaderAPIKey                 Header = `x-api-key`
 	HeaderContentType            Header = `content-type`
 	HeaderAccept                 Header = `accept`
@@ -32,6 +34,7 @@ const (
 	// HeaderAppLang Custom HTTP Header
 	HeaderAppLang       Header = `x-app-lang`
 	HeaderAppDebug      Header = `x-app-debug`
+	HeaderAppDebugToken Header = `x-app-debug-token` // #nosec G101 -- This is a header name, not a credential
 	HeaderCreationSteps Header = `x-create-step`
 
 	// HeaderLangEN Lang Header
@@ -6,9 +6,11 @@ import (
 	"log/slog"
 	"net/http"
 
+	"go-skeleton/config"
 	apperr "go-skeleton/pkg/errors"
 	x "go-skeleton/pkg/errors/entity"
 	"go-skeleton/pkg/errors/general"
+	"go-skeleton/pkg/requestid"
 
 	"github.com/gin-gonic/gin"
 )
@@ -45,6 +47,7 @@ type ErrorResp struct {
 		Message string   `json:"message"`
 		Errors  []Errors `json:"errors,omitempty"`
 	} `json:"error"`
+	RequestID string `json:"request_id,omitempty"`
 }
 
 type Errors struct {
@@ -78,7 +81,7 @@ func ResponseError(c *gin.Context, err error, errMessages ...string) {
 	debugMode := false
 	lang := HeaderLangEN.String()
 
-	if c.GetHeader(HeaderAppDebug.String()) == "true" {
+	if c.GetHeader(HeaderAppDebug.String()) == "true" && debugAllowed(c, config.Current().App.Debug) {
 		debugMode = true
 	}
 
@@ -100,7 +103,7 @@ func ResponseError(c *gin.Context, err error, errMessages ...string) {
 	statusCode, displayError := apperr.Compile(apperr.INTERNAL, err, lang, debugMode)
 	statusStr := http.StatusText(statusCode)
 
-	slog.ErrorContext(c, displayError.Error())
+	slog.ErrorContext(c.Request.Context(), displayError.Error(), slog.Int("status", statusCode))
 
 	errResp := ErrorResp{
 		Error: struct {
@@ -111,6 +114,7 @@ func ResponseError(c *gin.Context, err error, errMessages ...string) {
 			Code:    displayError.Code,
 			Message: displayError.Message,
 		},
+		RequestID: requestid.FromContext(c.Request.Context()),
 	}
 
 	if len(errMessages) > 0 {
@@ -1,10 +1,16 @@
 package common
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	x "go-skeleton/pkg/errors/entity"
+	"go-skeleton/pkg/errors/sql"
+	"go-skeleton/pkg/requestid"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,6 +29,27 @@ func TestResponseError(t *testing.T) {
 	t.Skip("Skipping due to gin build constraints")
 }
 
+func TestResponseError_IncludesRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(requestid.GinMiddleware())
+	router.GET("/", func(c *gin.Context) {
+		ResponseError(c, x.NewWithCode(sql.CodeSQLRecordDoesNotExist, "record not found"))
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(HeaderRequestID.String(), "abc-123")
+	router.ServeHTTP(w, req)
+
+	var resp ErrorResp
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, sql.CodeSQLRecordDoesNotExist, resp.Error.Code)
+	assert.Equal(t, "abc-123", resp.RequestID)
+}
+
 func TestSuccessResp_Structure(t *testing.T) {
 	resp := SuccessResp{
 		Message:    "success",
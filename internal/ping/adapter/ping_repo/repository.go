@@ -3,19 +3,19 @@ package pingrepo
 import (
 	"context"
 	"go-skeleton/internal/ping/core/domain"
+	"go-skeleton/pkg/database"
 	pkgErr "go-skeleton/pkg/errors/entity"
 	"go-skeleton/pkg/logger"
 
 	"github.com/go-redis/redis/v8"
-	"github.com/jmoiron/sqlx"
 )
 
 type PingRepository struct {
-	db    *sqlx.DB
+	db    *database.Cluster
 	cache *redis.Client
 }
 
-func NewPingRepository(db *sqlx.DB, cache *redis.Client) PingRepository {
+func NewPingRepository(db *database.Cluster, cache *redis.Client) PingRepository {
 	return PingRepository{
 		db:    db,
 		cache: cache,
@@ -24,11 +24,11 @@ func NewPingRepository(db *sqlx.DB, cache *redis.Client) PingRepository {
 
 func (r *PingRepository) Ping(ctx context.Context, resp *domain.Ping) error {
 	if r.db != nil {
-		err := r.db.PingContext(ctx)
+		err := r.db.Primary().PingContext(ctx)
 		if err != nil {
 			return pkgErr.Wrap(err, "ping database repository")
 		}
-		logger.Info("Pinging database repository")
+		logger.FromContext(ctx).Info("Pinging database repository")
 	}
 
 	if r.cache != nil {
@@ -37,7 +37,7 @@ func (r *PingRepository) Ping(ctx context.Context, resp *domain.Ping) error {
 			return pkgErr.Wrap(err, "ping cache repository")
 		}
 
-		logger.Info("Pinging cache repository")
+		logger.FromContext(ctx).Info("Pinging cache repository")
 	}
 
 	result := PingResponse{
@@ -8,7 +8,6 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"go.uber.org/zap"
 )
 
 type PingHandler struct {
@@ -24,13 +23,10 @@ func NewPingHandler(
 }
 
 func (h *PingHandler) Ping(c *gin.Context) {
-	logger.Info("Ping endpoint called",
-		zap.String("remote_addr", c.ClientIP()),
-		zap.String("user_agent", c.Request.UserAgent()),
-	)
+	logger.FromContext(c.Request.Context()).Info("Ping endpoint called")
 
 	var resp domain.Ping
-	err := h.PingService.Ping(c, &resp)
+	err := h.PingService.Ping(c.Request.Context(), &resp)
 	if err != nil {
 		httpcommon.ResponseError(c, err)
 		return
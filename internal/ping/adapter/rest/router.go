@@ -1,6 +1,8 @@
 package rest
 
 import (
+	"go-skeleton/pkg/logger"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -16,5 +18,5 @@ func NewRouter(handler *PingHandler) *Router {
 
 // RegisterPingRoutes registers ping-specific routes to the provided router
 func (r *Router) RegisterPingRoutes(router *gin.Engine) {
-	router.GET("/ping", r.handler.Ping)
+	router.GET("/ping", logger.ModuleMiddleware("ping"), r.handler.Ping)
 }
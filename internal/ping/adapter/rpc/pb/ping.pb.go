@@ -0,0 +1,173 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.4
+// 	protoc        v5.29.3
+// source: internal/ping/adapter/rpc/pb/ping.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingRequest) Reset() {
+	*x = PingRequest{}
+	mi := &file_internal_ping_adapter_rpc_pb_ping_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingRequest) ProtoMessage() {}
+
+func (x *PingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ping_adapter_rpc_pb_ping_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingRequest.ProtoReflect.Descriptor instead.
+func (*PingRequest) Descriptor() ([]byte, []int) {
+	return file_internal_ping_adapter_rpc_pb_ping_proto_rawDescGZIP(), []int{0}
+}
+
+type PingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PingMessage   string                 `protobuf:"bytes,1,opt,name=ping_message,json=pingMessage,proto3" json:"ping_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingResponse) Reset() {
+	*x = PingResponse{}
+	mi := &file_internal_ping_adapter_rpc_pb_ping_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingResponse) ProtoMessage() {}
+
+func (x *PingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ping_adapter_rpc_pb_ping_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingResponse.ProtoReflect.Descriptor instead.
+func (*PingResponse) Descriptor() ([]byte, []int) {
+	return file_internal_ping_adapter_rpc_pb_ping_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PingResponse) GetPingMessage() string {
+	if x != nil {
+		return x.PingMessage
+	}
+	return ""
+}
+
+var File_internal_ping_adapter_rpc_pb_ping_proto protoreflect.FileDescriptor
+
+var file_internal_ping_adapter_rpc_pb_ping_proto_rawDesc = string([]byte{
+	0x0a, 0x27, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x69, 0x6e, 0x67, 0x2f,
+	0x61, 0x64, 0x61, 0x70, 0x74, 0x65, 0x72, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x2f, 0x70,
+	0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x70, 0x69, 0x6e, 0x67, 0x2e,
+	0x76, 0x31, 0x22, 0x0d, 0x0a, 0x0b, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x31, 0x0a, 0x0c, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x32, 0x42, 0x0a, 0x0b, 0x50, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x33, 0x0a, 0x04, 0x50, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x2e, 0x70, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x15, 0x2e, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2d, 0x5a, 0x2b, 0x67, 0x6f, 0x2d, 0x73,
+	0x6b, 0x65, 0x6c, 0x65, 0x74, 0x6f, 0x6e, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x2f, 0x70, 0x69, 0x6e, 0x67, 0x2f, 0x61, 0x64, 0x61, 0x70, 0x74, 0x65, 0x72, 0x2f, 0x72, 0x70,
+	0x63, 0x2f, 0x70, 0x62, 0x3b, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_internal_ping_adapter_rpc_pb_ping_proto_rawDescOnce sync.Once
+	file_internal_ping_adapter_rpc_pb_ping_proto_rawDescData []byte
+)
+
+func file_internal_ping_adapter_rpc_pb_ping_proto_rawDescGZIP() []byte {
+	file_internal_ping_adapter_rpc_pb_ping_proto_rawDescOnce.Do(func() {
+		file_internal_ping_adapter_rpc_pb_ping_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_internal_ping_adapter_rpc_pb_ping_proto_rawDesc), len(file_internal_ping_adapter_rpc_pb_ping_proto_rawDesc)))
+	})
+	return file_internal_ping_adapter_rpc_pb_ping_proto_rawDescData
+}
+
+var file_internal_ping_adapter_rpc_pb_ping_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_internal_ping_adapter_rpc_pb_ping_proto_goTypes = []any{
+	(*PingRequest)(nil),  // 0: ping.v1.PingRequest
+	(*PingResponse)(nil), // 1: ping.v1.PingResponse
+}
+var file_internal_ping_adapter_rpc_pb_ping_proto_depIdxs = []int32{
+	0, // 0: ping.v1.PingService.Ping:input_type -> ping.v1.PingRequest
+	1, // 1: ping.v1.PingService.Ping:output_type -> ping.v1.PingResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_internal_ping_adapter_rpc_pb_ping_proto_init() }
+func file_internal_ping_adapter_rpc_pb_ping_proto_init() {
+	if File_internal_ping_adapter_rpc_pb_ping_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_ping_adapter_rpc_pb_ping_proto_rawDesc), len(file_internal_ping_adapter_rpc_pb_ping_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_internal_ping_adapter_rpc_pb_ping_proto_goTypes,
+		DependencyIndexes: file_internal_ping_adapter_rpc_pb_ping_proto_depIdxs,
+		MessageInfos:      file_internal_ping_adapter_rpc_pb_ping_proto_msgTypes,
+	}.Build()
+	File_internal_ping_adapter_rpc_pb_ping_proto = out.File
+	file_internal_ping_adapter_rpc_pb_ping_proto_goTypes = nil
+	file_internal_ping_adapter_rpc_pb_ping_proto_depIdxs = nil
+}
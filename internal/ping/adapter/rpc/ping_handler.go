@@ -0,0 +1,42 @@
+package rpc
+
+import (
+	"context"
+	"go-skeleton/internal/ping/adapter/rpc/pb"
+	"go-skeleton/internal/ping/core/domain"
+	"go-skeleton/internal/ping/core/service"
+	"go-skeleton/pkg/logger"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type PingHandler struct {
+	pb.UnimplementedPingServiceServer
+	PingService *service.PingService
+}
+
+func NewPingHandler(
+	pingService *service.PingService,
+) PingHandler {
+	return PingHandler{
+		PingService: pingService,
+	}
+}
+
+func (h *PingHandler) Ping(ctx context.Context, _ *pb.PingRequest) (*pb.PingResponse, error) {
+	ctx = logger.WithModule(ctx, "ping")
+	logger.FromContext(ctx).Info("Ping gRPC method called")
+
+	var resp domain.Ping
+	err := h.PingService.Ping(ctx, &resp)
+	if err != nil {
+		logger.FromContext(ctx).Error("Ping gRPC method failed", zap.Error(err))
+		return nil, status.Error(codes.Unavailable, "ping failed")
+	}
+
+	return &pb.PingResponse{
+		PingMessage: resp.Message,
+	}, nil
+}
@@ -0,0 +1,67 @@
+//go:build integration
+// +build integration
+
+package rpc_test
+
+import (
+	"context"
+	"go-skeleton/internal/ping/adapter/rpc/pb"
+	"os"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// TestPingServiceIntegration tests the ping gRPC service against a running server
+func TestPingServiceIntegration(t *testing.T) {
+	// Skip if not running integration tests
+	if os.Getenv("INTEGRATION_TEST") != "true" {
+		t.Skip("Skipping integration test. Set INTEGRATION_TEST=true to run")
+	}
+
+	conn, err := grpc.NewClient(getTestGRPCAddr(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to create gRPC client: %v", err)
+	}
+	defer conn.Close()
+
+	t.Run("Ping Method", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		resp, err := pb.NewPingServiceClient(conn).Ping(ctx, &pb.PingRequest{})
+		if err != nil {
+			t.Fatalf("Failed to call Ping: %v", err)
+		}
+
+		if resp.GetPingMessage() != "ping from repository" {
+			t.Errorf("Expected ping_message 'ping from repository', got '%s'", resp.GetPingMessage())
+		}
+	})
+
+	t.Run("Health Check", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		if err != nil {
+			t.Fatalf("Failed to call health check: %v", err)
+		}
+
+		if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("Expected status SERVING, got %s", resp.GetStatus())
+		}
+	})
+}
+
+// Helper function to get test gRPC address
+func getTestGRPCAddr() string {
+	addr := os.Getenv("TEST_GRPC_ADDR")
+	if addr == "" {
+		addr = "localhost:1999"
+	}
+	return addr
+}
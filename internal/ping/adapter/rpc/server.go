@@ -0,0 +1,22 @@
+package rpc
+
+import (
+	"go-skeleton/internal/ping/adapter/rpc/pb"
+
+	"google.golang.org/grpc"
+)
+
+type Server struct {
+	handler *PingHandler
+}
+
+func NewServer(handler *PingHandler) *Server {
+	return &Server{
+		handler: handler,
+	}
+}
+
+// RegisterPingService registers the ping gRPC service to the provided server
+func (s *Server) RegisterPingService(server grpc.ServiceRegistrar) {
+	pb.RegisterPingServiceServer(server, s.handler)
+}
@@ -4,16 +4,20 @@ import (
 	dicontainer "go-skeleton/container"
 	pingrepo "go-skeleton/internal/ping/adapter/ping_repo"
 	restHandl "go-skeleton/internal/ping/adapter/rest"
+	rpcHandl "go-skeleton/internal/ping/adapter/rpc"
 	"go-skeleton/internal/ping/core/port"
 	"go-skeleton/internal/ping/core/service"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
 )
 
 type Module struct {
 	Service     *service.PingService
 	RestHandler *restHandl.PingHandler
 	Router      *restHandl.Router
+	RPCHandler  *rpcHandl.PingHandler
+	RPCServer   *rpcHandl.Server
 }
 
 func NewModule(container *dicontainer.Container) Module {
@@ -30,10 +34,16 @@ func NewModule(container *dicontainer.Container) Module {
 	restHandler := restHandl.NewPingHandler(&svc)
 	router := restHandl.NewRouter(&restHandler)
 
+	// Create gRPC handlers and service registrar
+	rpcHandler := rpcHandl.NewPingHandler(&svc)
+	rpcServer := rpcHandl.NewServer(&rpcHandler)
+
 	return Module{
 		Service:     &svc,
 		RestHandler: &restHandler,
 		Router:      router,
+		RPCHandler:  &rpcHandler,
+		RPCServer:   rpcServer,
 	}
 }
 
@@ -41,3 +51,8 @@ func NewModule(container *dicontainer.Container) Module {
 func (m *Module) RegisterRoutes(router *gin.Engine) {
 	m.Router.RegisterPingRoutes(router)
 }
+
+// RegisterGRPCServices registers all ping gRPC services to the provided server
+func (m *Module) RegisterGRPCServices(server grpc.ServiceRegistrar) {
+	m.RPCServer.RegisterPingService(server)
+}
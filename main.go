@@ -1,12 +1,15 @@
 package main
 
 import (
+	"fmt"
 	"go-skeleton/cmd"
 	"go-skeleton/cmd/app"
 	"go-skeleton/config"
+	"go-skeleton/migrations"
 	"go-skeleton/pkg/database"
 	"go-skeleton/pkg/logger"
 	"os"
+	"strconv"
 
 	_ "github.com/lib/pq"
 	"github.com/urfave/cli/v2"
@@ -14,17 +17,45 @@ import (
 )
 
 func main() {
-	app.Init()
-	defer app.ShutDown()
+	// Commands touching the database or serving traffic initialize the application, config commands do not
+	initApp := func(_ *cli.Context) error {
+		app.Init()
+		return nil
+	}
+	shutDownApp := func(_ *cli.Context) error {
+		app.ShutDown()
+		return nil
+	}
+	initMigration := func() (*database.MigrationManager, error) {
+		migration, err := database.InitMigration(config.Database, config.Migration, migrations.FS)
+		if err != nil {
+			logger.Error("Failed to initialize migration", zap.Error(err))
+		}
+		return migration, err
+	}
 
 	cliApp := cli.NewApp()
 	cliApp.Name = "skeleton: Template for fast bootstrapping"
 	cliApp.Version = "1.0.0"
+	cliApp.Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:    "config",
+			Aliases: []string{"c"},
+			Usage:   "base configuration file, overlays are looked up next to it",
+			EnvVars: []string{"CONFIG_FILE"},
+		},
+	}
+	cliApp.Before = func(c *cli.Context) error {
+		config.SetFile(c.String("config"))
+		return nil
+	}
 
 	cliApp.Commands = cli.Commands{
 		{
-			Name:  "server",
-			Usage: "Start server",
+			Name:   "server",
+			Usage:  "Start server",
+			Before: initApp,
+			After:  shutDownApp,
 			Action: func(c *cli.Context) error {
 				logger.Info("Starting server command")
 				cmd.StartServer(c.Context)
@@ -32,8 +63,10 @@ func main() {
 			},
 		},
 		{
-			Name:  "migrate",
-			Usage: "run db migrations",
+			Name:   "migrate",
+			Usage:  "run db migrations",
+			Before: initApp,
+			After:  shutDownApp,
 			Subcommands: []*cli.Command{
 				{
 					Name:  "create",
@@ -45,33 +78,71 @@ func main() {
 							Usage:    "name of the migration",
 							Required: true,
 						},
+						&cli.BoolFlag{
+							Name:  "go",
+							Usage: "create a Go migration instead of SQL files",
+						},
 					},
 					Action: func(c *cli.Context) error {
 						migrationName := c.String("name")
-						logger.Info("Creating new migration", zap.String("name", migrationName))
+						logger.Info("Creating new migration", zap.String("name", migrationName), zap.Bool("go", c.Bool("go")))
 
-						migration, err := database.InitMigration(config.Database)
+						migration, err := initMigration()
 						if err != nil {
-							logger.Error("Failed to initialize migration", zap.Error(err))
 							return err
 						}
 
+						if c.Bool("go") {
+							return migration.CreateGoMigration(migrationName)
+						}
 						err = migration.CreateMigration(migrationName)
 						return err
 					},
 				},
 				{
-					Name:  "up",
-					Usage: "apply all migrations",
+					Name:  "status",
+					Usage: "print the current version, the dirty flag and the pending migrations",
 					Action: func(_ *cli.Context) error {
-						logger.Info("Applying migrations up")
-						migration, err := database.InitMigration(config.Database)
+						migration, err := initMigration()
+						if err != nil {
+							return err
+						}
+
+						status, err := migration.Status()
 						if err != nil {
-							logger.Error("Failed to initialize migration", zap.Error(err))
+							logger.Error("Failed to read migration status", zap.Error(err))
 							return err
 						}
+						cmd.PrintMigrationStatus(os.Stdout, status)
+						return nil
+					},
+				},
+				{
+					Name:  "up",
+					Usage: "apply all pending migrations, or the next N with --steps",
+					Flags: []cli.Flag{
+						&cli.IntFlag{
+							Name:  "steps",
+							Usage: "number of migrations to apply, 0 applies all of them",
+						},
+					},
+					Action: func(c *cli.Context) error {
+						steps := c.Int("steps")
+						if steps < 0 {
+							return cli.Exit("--steps must be positive", 1)
+						}
 
-						err = migration.ApplyMigrations()
+						logger.Info("Applying migrations up", zap.Int("steps", steps))
+						migration, err := initMigration()
+						if err != nil {
+							return err
+						}
+
+						if steps > 0 {
+							err = migration.ApplyMigrationsSteps(steps)
+						} else {
+							err = migration.ApplyMigrations()
+						}
 						if err != nil {
 							logger.Error("Failed to apply migrations", zap.Error(err))
 						} else {
@@ -82,16 +153,44 @@ func main() {
 				},
 				{
 					Name:  "down",
-					Usage: "apply all migrations",
-					Action: func(_ *cli.Context) error {
-						logger.Info("Rolling back migrations")
-						migration, err := database.InitMigration(config.Database)
+					Usage: "roll back the last migration, the last N with --steps or all of them with --all",
+					Flags: []cli.Flag{
+						&cli.IntFlag{
+							Name:  "steps",
+							Usage: "number of migrations to roll back",
+							Value: 1,
+						},
+						&cli.BoolFlag{
+							Name:  "all",
+							Usage: "roll back every migration, asks for confirmation",
+						},
+						&cli.BoolFlag{
+							Name:    "yes",
+							Aliases: []string{"y"},
+							Usage:   "do not ask for confirmation",
+						},
+					},
+					Action: func(c *cli.Context) error {
+						steps := c.Int("steps")
+						if steps < 1 {
+							return cli.Exit("--steps must be positive", 1)
+						}
+						if c.Bool("all") && !c.Bool("yes") &&
+							!cmd.Confirm(os.Stdin, os.Stdout, "Roll back ALL migrations? Every table they created will be dropped") {
+							return cli.Exit("aborted", 1)
+						}
+
+						logger.Info("Rolling back migrations", zap.Int("steps", steps), zap.Bool("all", c.Bool("all")))
+						migration, err := initMigration()
 						if err != nil {
-							logger.Error("Failed to initialize migration", zap.Error(err))
 							return err
 						}
 
-						err = migration.RollbackMigration()
+						if c.Bool("all") {
+							err = migration.RollbackAllMigrations()
+						} else {
+							err = migration.RollbackMigrationsSteps(steps)
+						}
 						if err != nil {
 							logger.Error("Failed to rollback migration", zap.Error(err))
 						} else {
@@ -100,6 +199,99 @@ func main() {
 						return err
 					},
 				},
+				{
+					Name:      "goto",
+					Usage:     "migrate up or down to the given version",
+					ArgsUsage: "<version>",
+					Action: func(c *cli.Context) error {
+						version, err := strconv.ParseUint(c.Args().First(), 10, 64)
+						if err != nil {
+							return cli.Exit(fmt.Sprintf("invalid version %q", c.Args().First()), 1)
+						}
+
+						logger.Info("Migrating to version", zap.Uint64("version", version))
+						migration, err := initMigration()
+						if err != nil {
+							return err
+						}
+
+						if err := migration.MigrateTo(uint(version)); err != nil {
+							logger.Error("Failed to migrate to version", zap.Error(err))
+							return err
+						}
+						return nil
+					},
+				},
+				cmd.ForceCommand(func(version int) error {
+					logger.Info("Forcing migration version", zap.Int("version", version))
+					migration, err := initMigration()
+					if err != nil {
+						return err
+					}
+
+					if err := migration.Force(version); err != nil {
+						logger.Error("Failed to force migration version", zap.Error(err))
+						return err
+					}
+					return nil
+				}),
+			},
+		},
+		{
+			Name:  "config",
+			Usage: "inspect the configuration",
+			Subcommands: []*cli.Command{
+				{
+					Name:  "validate",
+					Usage: "load and validate the configuration without starting anything",
+					Action: func(_ *cli.Context) error {
+						if _, err := config.Load(); err != nil {
+							return cli.Exit(err.Error(), 1)
+						}
+						fmt.Println("configuration is valid")
+						return nil
+					},
+				},
+				{
+					Name:  "schema",
+					Usage: "print the JSON Schema, the markdown reference or the sample file of the configuration",
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:    "format",
+							Aliases: []string{"f"},
+							Usage:   "output format: json, markdown or sample",
+							Value:   cmd.FormatJSON,
+						},
+					},
+					Action: func(c *cli.Context) error {
+						if err := cmd.PrintSchema(os.Stdout, c.String("format")); err != nil {
+							return cli.Exit(err.Error(), 1)
+						}
+						return nil
+					},
+				},
+				{
+					Name:  "print",
+					Usage: "print the effective configuration and where every value comes from, secrets are masked",
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:    "format",
+							Aliases: []string{"f"},
+							Usage:   "output format: yaml or json",
+							Value:   cmd.FormatYAML,
+						},
+						&cli.StringFlag{
+							Name:  "diff",
+							Usage: "only print the keys whose value differs with this configuration file, read without overlays or environment variables",
+						},
+					},
+					Action: func(c *cli.Context) error {
+						if err := cmd.PrintConfig(os.Stdout, c.String("format"), c.String("diff")); err != nil {
+							return cli.Exit(err.Error(), 1)
+						}
+						return nil
+					},
+				},
 			},
 		},
 	}
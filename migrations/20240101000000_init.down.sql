@@ -0,0 +1 @@
+-- Baseline of the schema, nothing to undo
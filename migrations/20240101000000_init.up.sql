@@ -0,0 +1 @@
+-- Baseline of the schema, the embedded migrations need at least one SQL file
@@ -0,0 +1,9 @@
+// Package migrations embeds the SQL migrations into the binary
+package migrations
+
+import "embed"
+
+// FS holds the SQL migrations of this directory, files not named <version>_<title>.(up|down).sql are ignored
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,23 @@
+package migrations_test
+
+import (
+	"go-skeleton/migrations"
+	"go-skeleton/pkg/database"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrations(t *testing.T) {
+	mm := database.NewMigrationManager(database.DefaultMigrationsDir, migrations.FS)
+
+	files, err := mm.Migrations()
+	assert.NoError(t, err)
+
+	seen := map[uint]string{}
+	for _, file := range files {
+		previous, ok := seen[file.Version]
+		assert.False(t, ok, "version %d is used by %s and %s", file.Version, previous, file.Name)
+		seen[file.Version] = file.Name
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"go-skeleton/config"
 	"go-skeleton/pkg/logger"
+	"go-skeleton/pkg/retry"
+	"go-skeleton/pkg/tracing"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -15,29 +17,45 @@ var (
 	RedisClient *redis.Client
 )
 
-func Init(cfg config.CacheConfig) {
-	// Create Redis client options
-	options := &redis.Options{
+// Init creates the Redis client and waits for Redis following the startup retry policy.
+// When Redis stays unreachable the process exits, unless startup.Degraded keeps the client for later recovery.
+func Init(cfg config.CacheConfig, startup config.StartupConfig) {
+	// Create Redis client
+	client := redis.NewClient(newOptions(cfg))
+
+	// Trace every command sent through the client
+	client.AddHook(tracing.NewRedisHook(nil))
+
+	// Test connection, every ping is bounded so a hanging dial does not use up the whole retry deadline
+	ping := func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		return client.Ping(ctx).Err()
+	}
+	if err := retry.Do(context.Background(), "redis", retry.NewPolicy(startup), ping); err != nil {
+		if !startup.Degraded {
+			_ = client.Close()
+			logger.Fatal("failed to connect to Redis", zap.Error(err))
+		}
+		logger.Warn("starting without Redis, readiness fails until it is reachable", zap.Error(err))
+	}
+
+	RedisClient = client
+}
+
+// newOptions builds the Redis client options from cfg, including the ACL credentials and database number
+func newOptions(cfg config.CacheConfig) *redis.Options {
+	return &redis.Options{
 		Addr:         fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+		Username:     cfg.Username,
+		Password:     cfg.Password,
+		DB:           cfg.DB,
 		PoolSize:     cfg.PoolSize,
 		DialTimeout:  cfg.DialTimeout,
 		ReadTimeout:  cfg.ReadTimeout,
 		WriteTimeout: cfg.WriteTimeout,
 		IdleTimeout:  cfg.IdleTimeout,
 	}
-
-	// Create Redis client
-	client := redis.NewClient(options)
-
-	// Test connection
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	_, err := client.Ping(ctx).Result()
-	cancel()
-	if err != nil {
-		logger.Fatal("failed to connect to Redis", zap.Error(err))
-	}
-
-	RedisClient = client
 }
 
 func CloseCache() {
@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"go-skeleton/config"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOptions(t *testing.T) {
+	options := newOptions(config.CacheConfig{
+		Host:        "redis",
+		Port:        6380,
+		Username:    "app",
+		Password:    "s3cret",
+		DB:          2,
+		PoolSize:    10,
+		DialTimeout: time.Second,
+	})
+
+	assert.Equal(t, "redis:6380", options.Addr)
+	assert.Equal(t, "app", options.Username)
+	assert.Equal(t, "s3cret", options.Password)
+	assert.Equal(t, 2, options.DB)
+	assert.Equal(t, 10, options.PoolSize)
+	assert.Equal(t, time.Second, options.DialTimeout)
+}
+
+func TestNewOptions_SecretReference(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "redis_password")
+	assert.NoError(t, os.WriteFile(secret, []byte("s3cret\n"), 0o600))
+
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	t.Setenv("ENVIRONMENT", "test")
+	t.Setenv("REDIS_PASSWORD", "file://"+secret)
+	t.Setenv("REDIS_AUTH_USER", "app")
+	t.Setenv("REDIS_USERNAME", "env:REDIS_AUTH_USER")
+
+	cfg, err := config.Load()
+
+	assert.NoError(t, err)
+	options := newOptions(cfg.RedisCache)
+	assert.Equal(t, "s3cret", options.Password)
+	assert.Equal(t, "app", options.Username)
+}
@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-skeleton/pkg/logger"
+	"hash/crc32"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"go.uber.org/zap"
+)
+
+// autoMigrateLockPoll is the interval between attempts to take the migration lock held by another instance
+const autoMigrateLockPoll = time.Second
+
+// migrationLockKey is the advisory lock held while migrating, advisory locks are scoped to the database
+var migrationLockKey = int64(crc32.ChecksumIEEE([]byte("go-skeleton:auto-migrate")))
+
+// AutoMigrate applies the pending migrations while holding a Postgres advisory lock, so only one instance migrates
+// and the others wait for it up to timeout, 0 waits without limit. It refuses to migrate when the schema is dirty
+// or newer than the migrations known to this binary.
+func (mm *MigrationManager) AutoMigrate(ctx context.Context, timeout time.Duration) error {
+	// The timeout only bounds the wait for the lock, a migration that started is never interrupted as that would
+	// leave the schema dirty
+	m, err := mm.getMigrate(context.WithoutCancel(ctx))
+	if err != nil {
+		CloseMigrationDB()
+		return err
+	}
+	defer closeMigrate(m)
+
+	lockCtx, cancel := ctx, context.CancelFunc(func() {})
+	if timeout > 0 {
+		lockCtx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	defer cancel()
+
+	conn, err := MigrationDB.Conn(lockCtx)
+	if err != nil {
+		return fmt.Errorf("failed to get a connection for the migration lock: %w", err)
+	}
+	defer conn.Close()
+
+	if err := acquireLock(lockCtx, conn, migrationLockKey); err != nil {
+		return err
+	}
+	defer func() {
+		// The lock is released with the session anyway, a failed unlock only delays the other instances
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockKey); err != nil {
+			logger.Warn("failed to release the migration lock", zap.Error(err))
+		}
+	}()
+
+	files, err := mm.Migrations()
+	if err != nil {
+		return err
+	}
+
+	version, dirty, err := m.Version()
+	applied := err == nil
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return fmt.Errorf("failed to read current version: %w", err)
+	}
+	if err := checkSchema(version, applied, dirty, files); err != nil {
+		return err
+	}
+
+	if err := m.Up(); err != nil {
+		if errors.Is(err, migrate.ErrNoChange) {
+			logger.Info("database schema is up to date", zap.Uint("version", version))
+			return nil
+		}
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	newVersion, _, _ := m.Version()
+	logger.Info("database migrations applied", zap.Uint("from", version), zap.Uint("to", newVersion))
+	return nil
+}
+
+// closeMigrate closes m together with the migration database its driver owns
+func closeMigrate(m *migrate.Migrate) {
+	if sourceErr, dbErr := m.Close(); sourceErr != nil || dbErr != nil {
+		logger.Error("failed to close migration instance", zap.NamedError("source", sourceErr), zap.NamedError("db", dbErr))
+	}
+	MigrationDB = nil
+}
+
+// acquireLock takes the advisory lock key on conn, waiting for the instance holding it until ctx is done
+func acquireLock(ctx context.Context, conn *sql.Conn, key int64) error {
+	for attempt := 1; ; attempt++ {
+		var locked bool
+		if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&locked); err != nil {
+			return fmt.Errorf("failed to take the migration lock: %w", err)
+		}
+		if locked {
+			return nil
+		}
+
+		if attempt == 1 {
+			logger.Info("waiting for another instance to finish the database migrations")
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for the migration lock held by another instance: %w", ctx.Err())
+		case <-time.After(autoMigrateLockPoll):
+		}
+	}
+}
+
+// checkSchema returns an error when the schema is dirty or newer than the latest known migration
+func checkSchema(version uint, applied, dirty bool, files []MigrationFile) error {
+	if dirty {
+		return fmt.Errorf("database schema is dirty at version %d, fix it by hand and run migrate force", version)
+	}
+	if !applied {
+		return nil
+	}
+
+	var latest uint
+	if len(files) > 0 {
+		latest = files[len(files)-1].Version
+	}
+	if version > latest {
+		return fmt.Errorf("database schema version %d is newer than the latest migration %d known to this binary", version, latest)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"go-skeleton/pkg/logger"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// BatchFunc processes at most limit rows and returns how many it processed
+type BatchFunc func(ctx context.Context, limit int) (int64, error)
+
+// Batch calls fn until it processes fewer than size rows, logging the progress after every batch under name.
+// fn must make progress, for instance by only selecting rows that are not migrated yet, or Batch never ends.
+func Batch(ctx context.Context, name string, size int, fn BatchFunc) (int64, error) {
+	if size <= 0 {
+		return 0, fmt.Errorf("batch %s: size must be positive", name)
+	}
+
+	start := time.Now()
+	var total int64
+	for batch := 1; ; batch++ {
+		if err := ctx.Err(); err != nil {
+			return total, fmt.Errorf("batch %s stopped after %d rows: %w", name, total, err)
+		}
+
+		n, err := fn(ctx, size)
+		if err != nil {
+			return total, fmt.Errorf("batch %s failed after %d rows: %w", name, total, err)
+		}
+		total += n
+
+		logger.FromContext(ctx).Info("migration batch done",
+			zap.String("batch", name),
+			zap.Int("number", batch),
+			zap.Int64("rows", n),
+			zap.Int64("total", total),
+			zap.Duration("elapsed", time.Since(start)),
+		)
+		if n < int64(size) {
+			return total, nil
+		}
+	}
+}
+
+// BatchExec runs query in tx until it affects fewer than size rows. The query receives size as $1 and args as
+// $2 onwards, and must only touch rows that still need migrating, for instance:
+//
+//	UPDATE users SET email_lower = lower(email)
+//	WHERE id IN (SELECT id FROM users WHERE email_lower IS NULL LIMIT $1)
+func BatchExec(ctx context.Context, tx *sqlx.Tx, name, query string, size int, args ...any) (int64, error) {
+	return Batch(ctx, name, size, func(ctx context.Context, limit int) (int64, error) {
+		result, err := tx.ExecContext(ctx, query, append([]any{limit}, args...)...)
+		if err != nil {
+			return 0, err
+		}
+		return result.RowsAffected()
+	})
+}
@@ -0,0 +1,167 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"go-skeleton/pkg/logger"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+const (
+	StrategyRoundRobin       = "round-robin"
+	StrategyLeastConnections = "least-connections"
+)
+
+// Replica is a read replica with the outcome of its last health check
+type Replica struct {
+	Name    string
+	DB      *sqlx.DB
+	healthy atomic.Bool
+}
+
+// NewReplica returns a replica that is considered healthy until a check fails
+func NewReplica(name string, db *sqlx.DB) *Replica {
+	r := &Replica{Name: name, DB: db}
+	r.healthy.Store(true)
+	return r
+}
+
+// Healthy reports whether the last health check of the replica succeeded
+func (r *Replica) Healthy() bool {
+	return r.healthy.Load()
+}
+
+// Cluster routes queries to the primary or to its read replicas
+type Cluster struct {
+	primary  *sqlx.DB
+	replicas []*Replica
+	strategy string
+	next     atomic.Uint64
+}
+
+// NewCluster creates a cluster, reads are spread over the healthy replicas with the given strategy
+func NewCluster(primary *sqlx.DB, replicas []*Replica, strategy string) *Cluster {
+	return &Cluster{
+		primary:  primary,
+		replicas: replicas,
+		strategy: strategy,
+	}
+}
+
+// Primary returns the primary connection pool
+func (c *Cluster) Primary() *sqlx.DB {
+	return c.primary
+}
+
+// Replicas returns the read replicas
+func (c *Cluster) Replicas() []*Replica {
+	return c.replicas
+}
+
+// Writer returns the transaction of ctx or the primary, and pins the reads of the current pin scope to the primary
+// so a request reads its own writes even when the replicas lag behind
+func (c *Cluster) Writer(ctx context.Context) Querier {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+	PinPrimary(ctx)
+	return c.primary
+}
+
+// Reader returns the transaction of ctx, or a healthy replica unless the reads are pinned or no replica is healthy,
+// in which case it returns the primary
+func (c *Cluster) Reader(ctx context.Context) Querier {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+	if IsPinned(ctx) {
+		return c.primary
+	}
+	if replica := c.pick(); replica != nil {
+		return replica.DB
+	}
+	return c.primary
+}
+
+// pick selects a healthy replica, starting at the round robin position so ties are spread evenly
+func (c *Cluster) pick() *Replica {
+	n := len(c.replicas)
+	if n == 0 {
+		return nil
+	}
+
+	start := int(c.next.Add(1)-1) % n
+	var best *Replica
+	bestInUse := 0
+	for i := 0; i < n; i++ {
+		replica := c.replicas[(start+i)%n]
+		if !replica.Healthy() {
+			continue
+		}
+		if c.strategy != StrategyLeastConnections {
+			return replica
+		}
+		if inUse := replica.DB.Stats().InUse; best == nil || inUse < bestInUse {
+			best, bestInUse = replica, inUse
+		}
+	}
+	return best
+}
+
+// CheckReplicas pings every replica and records whether it is healthy, changes are logged.
+// Each ping is bounded by timeout unless it is 0.
+func (c *Cluster) CheckReplicas(ctx context.Context, timeout time.Duration) {
+	for _, replica := range c.replicas {
+		checkCtx, cancel := ctx, context.CancelFunc(func() {})
+		if timeout > 0 {
+			checkCtx, cancel = context.WithTimeout(ctx, timeout)
+		}
+		err := replica.DB.PingContext(checkCtx)
+		cancel()
+
+		healthy := err == nil
+		if replica.healthy.Swap(healthy) == healthy {
+			continue
+		}
+		if healthy {
+			logger.Info("database replica is healthy again", zap.String("replica", replica.Name))
+		} else {
+			logger.Warn("database replica is unhealthy, reads fall back to other replicas or the primary",
+				zap.String("replica", replica.Name), zap.Error(err))
+		}
+	}
+}
+
+// MonitorReplicas checks the replicas every interval until ctx is done
+func (c *Cluster) MonitorReplicas(ctx context.Context, interval time.Duration) {
+	if len(c.replicas) == 0 || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.CheckReplicas(ctx, interval)
+		}
+	}
+}
+
+// Close closes the primary and every replica
+func (c *Cluster) Close() error {
+	var errs []error
+	for _, replica := range c.replicas {
+		errs = append(errs, replica.DB.Close())
+	}
+	if c.primary != nil {
+		errs = append(errs, c.primary.Close())
+	}
+	return errors.Join(errs...)
+}
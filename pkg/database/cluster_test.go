@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+// openLazy returns a pool that never connects, enough to check which pool a query is routed to
+func openLazy(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	db, err := sql.Open("postgres", "postgres://user@127.0.0.1:1/db?sslmode=disable&connect_timeout=1")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return sqlx.NewDb(db, "postgres")
+}
+
+func TestCluster_ReaderRoundRobin(t *testing.T) {
+	primary := openLazy(t)
+	first, second := NewReplica("replica-1", openLazy(t)), NewReplica("replica-2", openLazy(t))
+	cluster := NewCluster(primary, []*Replica{first, second}, StrategyRoundRobin)
+	ctx := context.Background()
+
+	assert.Same(t, first.DB, cluster.Reader(ctx))
+	assert.Same(t, second.DB, cluster.Reader(ctx))
+	assert.Same(t, first.DB, cluster.Reader(ctx))
+}
+
+func TestCluster_ReaderSkipsUnhealthyReplicas(t *testing.T) {
+	primary := openLazy(t)
+	first, second := NewReplica("replica-1", openLazy(t)), NewReplica("replica-2", openLazy(t))
+	cluster := NewCluster(primary, []*Replica{first, second}, StrategyLeastConnections)
+	ctx := context.Background()
+
+	first.healthy.Store(false)
+	assert.Same(t, second.DB, cluster.Reader(ctx))
+	assert.Same(t, second.DB, cluster.Reader(ctx))
+
+	second.healthy.Store(false)
+	assert.Same(t, primary, cluster.Reader(ctx))
+}
+
+func TestCluster_ReaderWithoutReplicas(t *testing.T) {
+	primary := openLazy(t)
+	cluster := NewCluster(primary, nil, StrategyRoundRobin)
+
+	assert.Same(t, primary, cluster.Reader(context.Background()))
+}
+
+func TestCluster_WriterPinsReadsToPrimary(t *testing.T) {
+	primary := openLazy(t)
+	replica := NewReplica("replica-1", openLazy(t))
+	cluster := NewCluster(primary, []*Replica{replica}, StrategyRoundRobin)
+	ctx := WithPinScope(context.Background())
+
+	assert.Same(t, replica.DB, cluster.Reader(ctx))
+	assert.Same(t, primary, cluster.Writer(ctx))
+	assert.Same(t, primary, cluster.Reader(ctx))
+
+	// A new scope, such as the next request, reads from the replicas again
+	assert.Same(t, replica.DB, cluster.Reader(WithPinScope(context.Background())))
+}
+
+func TestCluster_CheckReplicas(t *testing.T) {
+	replica := NewReplica("replica-1", openLazy(t))
+	cluster := NewCluster(openLazy(t), []*Replica{replica}, StrategyRoundRobin)
+
+	cluster.CheckReplicas(context.Background(), time.Second)
+
+	assert.False(t, replica.Healthy())
+}
@@ -1,41 +1,112 @@
 package database
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"go-skeleton/config"
 	"go-skeleton/pkg/logger"
+	"go-skeleton/pkg/retry"
+	"io/fs"
 
+	"github.com/XSAM/otelsql"
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq" // PostgreSQL driver
+	"github.com/lib/pq"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	"go.uber.org/zap"
 )
 
+// minMigrationConnections is the connections AutoMigrate holds at once: the advisory lock, the one golang-migrate
+// pins for its own locking and the transaction of a Go migration
+const minMigrationConnections = 3
+
 var (
+	// DBConn is the primary, DBCluster routes between it and the read replicas
 	DBConn      *sqlx.DB
+	DBCluster   *Cluster
 	MigrationDB *sqlx.DB
+
+	stopMonitor context.CancelFunc = func() {}
 )
 
-func Init(cfg config.DatabaseConfig) {
-	// Build connection string for PostgreSQL
-	dsn := cfg.ConnectionURL()
+// Init opens the connection pool and waits for Postgres following the startup retry policy.
+// When Postgres stays unreachable the process exits, unless startup.Degraded keeps the pool for later recovery.
+func Init(cfg config.DatabaseConfig, startup config.StartupConfig) {
+	connector, err := newConnector(cfg)
+	if err != nil {
+		logger.Fatal("failed to open database with sqlx", zap.Error(err))
+	}
+
+	// SQL connection for all operations, traced through the otelsql driver wrapper
+	db := openTraced(connector, cfg)
+
+	if err := retry.Do(context.Background(), "postgres", retry.NewPolicy(startup), db.PingContext); err != nil {
+		if !startup.Degraded {
+			_ = db.Close()
+			logger.Fatal("failed to connect to database with sqlx", zap.Error(err))
+		}
+		logger.Warn("starting without database, readiness fails until it is reachable", zap.Error(err))
+	}
+
+	SetSlowQueryThreshold(cfg.SlowQueryThreshold)
+	DBConn = db
+	DBCluster = NewCluster(db, openReplicas(cfg), cfg.Replicas.Strategy)
+
+	// Replicas are optional, an unreachable one only receives no reads until it recovers
+	DBCluster.CheckReplicas(context.Background(), cfg.Replicas.CheckInterval)
+	var ctx context.Context
+	ctx, stopMonitor = context.WithCancel(context.Background())
+	go DBCluster.MonitorReplicas(ctx, cfg.Replicas.CheckInterval)
+}
+
+// openReplicas opens a pool per replica host with the settings of the primary
+func openReplicas(cfg config.DatabaseConfig) []*Replica {
+	replicas := make([]*Replica, 0, len(cfg.Replicas.Hosts))
+	for _, hostPort := range cfg.Replicas.Hosts {
+		replicaCfg, err := cfg.Replica(hostPort)
+		if err != nil {
+			logger.Fatal("invalid database replica", zap.String("replica", hostPort), zap.Error(err))
+		}
+
+		connector, err := newConnector(replicaCfg)
+		if err != nil {
+			logger.Fatal("failed to open database replica with sqlx", zap.String("replica", hostPort), zap.Error(err))
+		}
+		replicas = append(replicas, NewReplica(hostPort, openTraced(connector, replicaCfg)))
+	}
+	return replicas
+}
+
+// openTraced opens a pool whose queries are wrapped in OpenTelemetry spans, no connection is made yet
+func openTraced(connector *pq.Connector, cfg config.DatabaseConfig) *sqlx.DB {
+	db := sqlx.NewDb(otelsql.OpenDB(connector, otelsql.WithAttributes(semconv.DBSystemPostgreSQL)), cfg.DriverName)
+	configurePool(db, cfg)
+	return db
+}
 
-	// SQL connection for all operations
-	db, err := sqlx.Connect(cfg.DriverName, dsn)
+// newConnector builds a Postgres connector from the connection URL of cfg whose sockets apply the read and write timeouts
+func newConnector(cfg config.DatabaseConfig) (*pq.Connector, error) {
+	connector, err := pq.NewConnector(cfg.ConnectionURL())
 	if err != nil {
-		logger.Fatal("failed to connect to database with sqlx", zap.Error(err))
+		return nil, err
 	}
 
-	// DB pool configuration
-	db.SetMaxOpenConns(cfg.ConnectionMaxOpen)
+	connector.Dialer(&timeoutDialer{readTimeout: cfg.ReadTimeout, writeTimeout: cfg.WriteTimeout})
+	return connector, nil
+}
+
+// configurePool applies the pool limits of cfg
+func configurePool(db *sqlx.DB, cfg config.DatabaseConfig) {
+	db.SetMaxOpenConns(cfg.MaxOpenConnections())
 	db.SetMaxIdleConns(cfg.ConnectionMaxIdle)
 	db.SetConnMaxLifetime(cfg.ConnectionMaxLifeTime)
-
-	DBConn = db
+	db.SetConnMaxIdleTime(cfg.ConnectionMaxIdleTime)
 }
 
 func CloseDB() {
-	if DBConn.DB != nil {
-		if err := DBConn.DB.Close(); err != nil {
+	stopMonitor()
+	if DBCluster != nil {
+		if err := DBCluster.Close(); err != nil {
 			logger.Fatal("failed to close database connection", zap.Error(err))
 		}
 	}
@@ -50,27 +121,34 @@ func CloseMigrationDB() {
 	}
 }
 
-func InitMigration(cfg config.DatabaseConfig) (*MigrationManager, error) {
-	// Create a separate database connection specifically for migrations
-	// Build connection string for PostgreSQL
-	dsn := cfg.ConnectionURL()
-
-	// SQL connection for all operations
-	db, err := sqlx.Connect(cfg.DriverName, dsn)
+// InitMigration opens the migration connection, the migrations are read from embedded unless MIGRATION_DIR is set
+func InitMigration(cfg config.DatabaseConfig, migrationCfg config.MigrationConfig, embedded fs.FS) (*MigrationManager, error) {
+	// Create a separate database connection specifically for migrations, without the timeouts of application queries
+	cfg = cfg.Migration()
+	connector, err := newConnector(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database with sqlx: %w", err)
 	}
 
-	// DB pool configuration
-	db.SetMaxOpenConns(cfg.ConnectionMaxOpen)
-	db.SetMaxIdleConns(cfg.ConnectionMaxIdle)
-	db.SetConnMaxLifetime(cfg.ConnectionMaxLifeTime)
+	db := sqlx.NewDb(sql.OpenDB(connector), cfg.DriverName)
+	if err := db.Ping(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to connect to database with sqlx: %w", err)
+	}
+
+	// DB pool configuration, migrating holds up to minMigrationConnections at once so a smaller limit would deadlock
+	configurePool(db, cfg)
+	if limit := cfg.MaxOpenConnections(); limit > 0 && limit < minMigrationConnections {
+		db.SetMaxOpenConns(minMigrationConnections)
+	}
 
 	MigrationDB = db
 
-	// Create a migration manager with the separate connection
-	migrationsDir := "migrations"
-	migrationManager := NewMigrationManager(migrationsDir)
+	// Create a migration manager with the separate connection, reading the embedded migrations unless a directory is set
+	if migrationCfg.Dir != "" {
+		return NewMigrationManager(migrationCfg.Dir, nil), nil
+	}
+	migrationManager := NewMigrationManager(DefaultMigrationsDir, embedded)
 
 	return migrationManager, nil
 }
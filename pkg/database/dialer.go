@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// timeoutDialer dials Postgres connections whose every read and write is bounded by a deadline,
+// so a dead peer fails the query instead of hanging it
+type timeoutDialer struct {
+	dialer       net.Dialer
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+}
+
+var _ pq.DialerContext = (*timeoutDialer)(nil)
+
+func (d *timeoutDialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, address)
+}
+
+func (d *timeoutDialer) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return d.DialContext(ctx, network, address)
+}
+
+func (d *timeoutDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	conn, err := d.dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	return &timeoutConn{Conn: conn, readTimeout: d.readTimeout, writeTimeout: d.writeTimeout}, nil
+}
+
+type timeoutConn struct {
+	net.Conn
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+}
+
+func (c *timeoutConn) Read(b []byte) (int, error) {
+	if c.readTimeout > 0 {
+		if err := c.Conn.SetReadDeadline(time.Now().Add(c.readTimeout)); err != nil {
+			return 0, err
+		}
+	}
+	return c.Conn.Read(b)
+}
+
+func (c *timeoutConn) Write(b []byte) (int, error) {
+	if c.writeTimeout > 0 {
+		if err := c.Conn.SetWriteDeadline(time.Now().Add(c.writeTimeout)); err != nil {
+			return 0, err
+		}
+	}
+	return c.Conn.Write(b)
+}
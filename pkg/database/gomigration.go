@@ -0,0 +1,206 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go-skeleton/pkg/logger"
+	"io"
+	"io/fs"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// goMigrationMarker starts the body the migration source returns for Go migrations, the driver runs the
+// registered function instead of sending it to Postgres
+const goMigrationMarker = "-- go migration "
+
+// GoMigrationFunc migrates inside tx, which is committed when it returns nil and rolled back otherwise
+type GoMigrationFunc func(ctx context.Context, tx *sqlx.Tx) error
+
+// GoMigration is a migration written in Go, applied in version order together with the SQL migrations
+type GoMigration struct {
+	Version uint
+	Name    string
+	Up      GoMigrationFunc
+	// Down is optional, rolling back a version without it only moves the version
+	Down GoMigrationFunc
+}
+
+var goMigrations = map[uint]GoMigration{}
+
+// RegisterGoMigration registers a Go migration, usually from an init function of the migrations package.
+// It panics when the version is registered twice or up is nil.
+func RegisterGoMigration(version uint, name string, up, down GoMigrationFunc) {
+	if up == nil {
+		panic(fmt.Sprintf("go migration %d_%s has no up function", version, name))
+	}
+	if existing, ok := goMigrations[version]; ok {
+		panic(fmt.Sprintf("go migration %d_%s is registered twice, first as %s", version, name, existing.Name))
+	}
+	goMigrations[version] = GoMigration{Version: version, Name: name, Up: up, Down: down}
+}
+
+// migrationSource is a golang-migrate source serving the SQL files of a file system and the Go migrations
+type migrationSource struct {
+	fsys         fs.FS
+	migrations   *source.Migrations
+	goMigrations map[uint]GoMigration
+}
+
+// newMigrationSource indexes the SQL files of fsys and the Go migrations, a version may only be used by one of them
+func newMigrationSource(fsys fs.FS, goMigrations map[uint]GoMigration) (*migrationSource, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &migrationSource{fsys: fsys, migrations: source.NewMigrations(), goMigrations: goMigrations}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		migration, err := source.DefaultParse(entry.Name())
+		if err != nil {
+			continue
+		}
+		if _, ok := goMigrations[migration.Version]; ok {
+			return nil, fmt.Errorf("migration version %d is used by %s and by a go migration", migration.Version, entry.Name())
+		}
+		if !s.migrations.Append(migration) {
+			return nil, fmt.Errorf("duplicate migration file %s", entry.Name())
+		}
+	}
+
+	for version, migration := range goMigrations {
+		s.migrations.Append(&source.Migration{Version: version, Identifier: migration.Name, Direction: source.Up})
+		if migration.Down != nil {
+			s.migrations.Append(&source.Migration{Version: version, Identifier: migration.Name, Direction: source.Down})
+		}
+	}
+	return s, nil
+}
+
+func (s *migrationSource) Open(string) (source.Driver, error) {
+	return nil, fmt.Errorf("the migration source cannot be opened from a URL")
+}
+
+func (s *migrationSource) Close() error {
+	return nil
+}
+
+func (s *migrationSource) First() (uint, error) {
+	if version, ok := s.migrations.First(); ok {
+		return version, nil
+	}
+	return 0, &fs.PathError{Op: "first", Path: ".", Err: fs.ErrNotExist}
+}
+
+func (s *migrationSource) Prev(version uint) (uint, error) {
+	if prev, ok := s.migrations.Prev(version); ok {
+		return prev, nil
+	}
+	return 0, &fs.PathError{Op: "prev for version " + strconv.FormatUint(uint64(version), 10), Path: ".", Err: fs.ErrNotExist}
+}
+
+func (s *migrationSource) Next(version uint) (uint, error) {
+	if next, ok := s.migrations.Next(version); ok {
+		return next, nil
+	}
+	return 0, &fs.PathError{Op: "next for version " + strconv.FormatUint(uint64(version), 10), Path: ".", Err: fs.ErrNotExist}
+}
+
+func (s *migrationSource) ReadUp(version uint) (io.ReadCloser, string, error) {
+	if migration, ok := s.migrations.Up(version); ok {
+		return s.read(migration)
+	}
+	return nil, "", &fs.PathError{Op: "read up for version " + strconv.FormatUint(uint64(version), 10), Path: ".", Err: fs.ErrNotExist}
+}
+
+func (s *migrationSource) ReadDown(version uint) (io.ReadCloser, string, error) {
+	if migration, ok := s.migrations.Down(version); ok {
+		return s.read(migration)
+	}
+	return nil, "", &fs.PathError{Op: "read down for version " + strconv.FormatUint(uint64(version), 10), Path: ".", Err: fs.ErrNotExist}
+}
+
+// read opens the SQL file of migration, or returns the marker of the Go migration
+func (s *migrationSource) read(migration *source.Migration) (io.ReadCloser, string, error) {
+	if _, ok := s.goMigrations[migration.Version]; ok {
+		marker := fmt.Sprintf("%s%d %s\n", goMigrationMarker, migration.Version, migration.Direction)
+		return io.NopCloser(strings.NewReader(marker)), migration.Identifier, nil
+	}
+
+	file, err := s.fsys.Open(migration.Raw)
+	if err != nil {
+		return nil, "", err
+	}
+	return file, migration.Identifier, nil
+}
+
+// goMigrationDriver runs the Go migrations in a transaction of db with ctx and passes the SQL ones to the wrapped
+// driver, whose Run has no context of its own
+type goMigrationDriver struct {
+	database.Driver
+	ctx          context.Context
+	db           *sqlx.DB
+	goMigrations map[uint]GoMigration
+}
+
+func (d *goMigrationDriver) Run(migration io.Reader) error {
+	body, err := io.ReadAll(migration)
+	if err != nil {
+		return err
+	}
+	if !bytes.HasPrefix(body, []byte(goMigrationMarker)) {
+		return d.Driver.Run(bytes.NewReader(body))
+	}
+
+	var version uint
+	var direction source.Direction
+	if _, err := fmt.Sscanf(string(body[len(goMigrationMarker):]), "%d %s", &version, &direction); err != nil {
+		return fmt.Errorf("invalid go migration marker %q: %w", body, err)
+	}
+	goMigration, ok := d.goMigrations[version]
+	if !ok {
+		return fmt.Errorf("go migration %d is not registered", version)
+	}
+
+	fn := goMigration.Up
+	if direction == source.Down {
+		fn = goMigration.Down
+	}
+	return runGoMigration(d.ctx, d.db, goMigration, direction, fn)
+}
+
+// runGoMigration runs fn in a transaction of db
+func runGoMigration(ctx context.Context, db *sqlx.DB, migration GoMigration, direction source.Direction, fn GoMigrationFunc) error {
+	start := time.Now()
+	log := logger.FromContext(ctx).With(
+		zap.Uint("version", migration.Version),
+		zap.String("name", migration.Name),
+		zap.String("direction", string(direction)),
+	)
+	log.Info("running go migration")
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin go migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	if err := fn(ctx, tx); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("go migration %d_%s failed: %w", migration.Version, migration.Name, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit go migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	log.Info("go migration done", zap.Duration("duration", time.Since(start)))
+	return nil
+}
@@ -0,0 +1,152 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func noopGoMigration(context.Context, *sqlx.Tx) error { return nil }
+
+// fakeMigrationDriver records the SQL migrations it runs, the methods it does not override panic
+type fakeMigrationDriver struct {
+	database.Driver
+	runs []string
+}
+
+func (f *fakeMigrationDriver) Run(migration io.Reader) error {
+	body, err := io.ReadAll(migration)
+	f.runs = append(f.runs, string(body))
+	return err
+}
+
+func testMigrationSource(t *testing.T) *migrationSource {
+	t.Helper()
+	sqlFiles := fstest.MapFS{
+		"1_add_users.up.sql":    {Data: []byte("CREATE TABLE users ();")},
+		"1_add_users.down.sql":  {Data: []byte("DROP TABLE users;")},
+		"3_add_orders.up.sql":   {Data: []byte("CREATE TABLE orders ();")},
+		"3_add_orders.down.sql": {Data: []byte("DROP TABLE orders;")},
+	}
+	gos := map[uint]GoMigration{
+		2: {Version: 2, Name: "backfill_users", Up: noopGoMigration},
+	}
+
+	s, err := newMigrationSource(sqlFiles, gos)
+	assert.NoError(t, err)
+	return s
+}
+
+func TestMigrationSource_Interleaves(t *testing.T) {
+	s := testMigrationSource(t)
+
+	first, err := s.First()
+	assert.NoError(t, err)
+	second, _ := s.Next(first)
+	third, _ := s.Next(second)
+	_, err = s.Next(third)
+
+	assert.Equal(t, []uint{1, 2, 3}, []uint{first, second, third})
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestMigrationSource_Read(t *testing.T) {
+	s := testMigrationSource(t)
+
+	r, name, err := s.ReadUp(1)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(r)
+	assert.Equal(t, "add_users", name)
+	assert.Equal(t, "CREATE TABLE users ();", string(body))
+
+	r, name, err = s.ReadUp(2)
+	assert.NoError(t, err)
+	body, _ = io.ReadAll(r)
+	assert.Equal(t, "backfill_users", name)
+	assert.Equal(t, goMigrationMarker+"2 up\n", string(body))
+
+	// Go migrations without down only move the version when rolled back
+	_, _, err = s.ReadDown(2)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestMigrationSource_DuplicateVersion(t *testing.T) {
+	sqlFiles := fstest.MapFS{"2_add_users.up.sql": {Data: []byte("CREATE TABLE users ();")}}
+
+	_, err := newMigrationSource(sqlFiles, map[uint]GoMigration{2: {Version: 2, Name: "backfill", Up: noopGoMigration}})
+
+	assert.ErrorContains(t, err, "migration version 2 is used by 2_add_users.up.sql and by a go migration")
+}
+
+func TestGoMigrationDriver_Run(t *testing.T) {
+	fake := &fakeMigrationDriver{}
+	d := &goMigrationDriver{Driver: fake, goMigrations: map[uint]GoMigration{}}
+
+	assert.NoError(t, d.Run(strings.NewReader("CREATE TABLE users ();")))
+	assert.ErrorContains(t, d.Run(strings.NewReader(goMigrationMarker+"2 up\n")), "go migration 2 is not registered")
+	assert.Equal(t, []string{"CREATE TABLE users ();"}, fake.runs)
+}
+
+func TestRegisterGoMigration(t *testing.T) {
+	t.Cleanup(func() { delete(goMigrations, 1) })
+
+	RegisterGoMigration(1, "backfill", noopGoMigration, nil)
+
+	assert.Equal(t, "backfill", goMigrations[1].Name)
+	assert.Panics(t, func() { RegisterGoMigration(1, "again", noopGoMigration, nil) })
+	assert.Panics(t, func() { RegisterGoMigration(2, "no_up", nil, nil) })
+}
+
+func TestMigrationManager_CreateGoMigration(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "migrations")
+	mm := NewMigrationManager(dir, nil)
+
+	assert.NoError(t, mm.CreateGoMigration("backfill_users"))
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*_backfill_users.go"))
+	if assert.Len(t, files, 1) {
+		_, err := parser.ParseFile(token.NewFileSet(), files[0], nil, 0)
+		assert.NoError(t, err)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	remaining := int64(25)
+	total, err := Batch(context.Background(), "backfill", 10, func(_ context.Context, limit int) (int64, error) {
+		n := min(remaining, int64(limit))
+		remaining -= n
+		return n, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(25), total)
+	assert.Zero(t, remaining)
+}
+
+func TestBatch_Errors(t *testing.T) {
+	failure := errors.New("failure")
+	total, err := Batch(context.Background(), "backfill", 10, func(context.Context, int) (int64, error) {
+		return 0, failure
+	})
+	assert.ErrorIs(t, err, failure)
+	assert.Zero(t, total)
+
+	_, err = Batch(context.Background(), "backfill", 0, nil)
+	assert.ErrorContains(t, err, "size must be positive")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = Batch(ctx, "backfill", 10, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+}
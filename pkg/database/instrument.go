@@ -0,0 +1,178 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"go-skeleton/pkg/errors/entity"
+	sqlerr "go-skeleton/pkg/errors/sql"
+	"go-skeleton/pkg/logger"
+	"go-skeleton/pkg/metrics"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// maxLoggedQueryLength bounds the normalized SQL written to the slow query log
+const maxLoggedQueryLength = 1000
+
+var (
+	slowQueryThreshold atomic.Int64
+
+	// Literals are replaced so the slow query log never contains values inlined in the SQL
+	stringLiteralRegexp = regexp.MustCompile(`'(?:[^']|'')*'`)
+	numberLiteralRegexp = regexp.MustCompile(`(^|[^$\w])\d+(?:\.\d+)?\b`)
+	whitespaceRegexp    = regexp.MustCompile(`\s+`)
+)
+
+// SetSlowQueryThreshold sets the duration above which queries are logged, 0 disables the log
+func SetSlowQueryThreshold(threshold time.Duration) {
+	slowQueryThreshold.Store(int64(threshold))
+}
+
+// Handle is a view of a Cluster whose queries are timed, counted and logged when slow under a repository name
+type Handle struct {
+	cluster    *Cluster
+	repository string
+}
+
+// For returns a handle whose queries are instrumented under the repository name
+func (c *Cluster) For(repository string) *Handle {
+	return &Handle{cluster: c, repository: repository}
+}
+
+// Reader is Cluster.Reader with instrumented queries
+func (h *Handle) Reader(ctx context.Context) Querier {
+	return Instrument(h.cluster.Reader(ctx), h.repository)
+}
+
+// Writer is Cluster.Writer with instrumented queries
+func (h *Handle) Writer(ctx context.Context) Querier {
+	return Instrument(h.cluster.Writer(ctx), h.repository)
+}
+
+// Instrument wraps q so every query is timed, counted and logged when slower than the slow query threshold,
+// and query errors are translated by sqlerr.Translate
+func Instrument(q Querier, repository string) Querier {
+	return &instrumentedQuerier{Querier: q, repository: repository}
+}
+
+type instrumentedQuerier struct {
+	Querier
+	repository string
+}
+
+func (q *instrumentedQuerier) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	start := time.Now()
+	result, err := q.Querier.ExecContext(ctx, query, args...)
+	return result, q.observe(ctx, "exec", query, len(args), start, err)
+}
+
+func (q *instrumentedQuerier) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := q.Querier.QueryContext(ctx, query, args...)
+	return rows, q.observe(ctx, "query", query, len(args), start, err)
+}
+
+func (q *instrumentedQuerier) QueryxContext(ctx context.Context, query string, args ...any) (*sqlx.Rows, error) {
+	start := time.Now()
+	rows, err := q.Querier.QueryxContext(ctx, query, args...)
+	return rows, q.observe(ctx, "query", query, len(args), start, err)
+}
+
+func (q *instrumentedQuerier) GetContext(ctx context.Context, dest any, query string, args ...any) error {
+	start := time.Now()
+	err := q.Querier.GetContext(ctx, dest, query, args...)
+	return q.observe(ctx, "get", query, len(args), start, err)
+}
+
+func (q *instrumentedQuerier) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
+	start := time.Now()
+	err := q.Querier.SelectContext(ctx, dest, query, args...)
+	return q.observe(ctx, "select", query, len(args), start, err)
+}
+
+func (q *instrumentedQuerier) NamedExecContext(ctx context.Context, query string, arg any) (sql.Result, error) {
+	start := time.Now()
+	result, err := q.Querier.NamedExecContext(ctx, query, arg)
+	return result, q.observe(ctx, "named_exec", query, 1, start, err)
+}
+
+func (q *instrumentedQuerier) PreparexContext(ctx context.Context, query string) (*sqlx.Stmt, error) {
+	start := time.Now()
+	stmt, err := q.Querier.PreparexContext(ctx, query)
+	return stmt, q.observe(ctx, "prepare", query, 0, start, err)
+}
+
+// observe records the latency and the error code of a query, logs it when slow and returns err translated by
+// sqlerr.Translate. Argument values are never logged.
+func (q *instrumentedQuerier) observe(ctx context.Context, operation, query string, args int, start time.Time, err error) error {
+	duration := time.Since(start)
+
+	var code entity.Code
+	label := ""
+	if err != nil {
+		code = queryErrorCode(operation, query, err)
+		label = strconv.FormatUint(uint64(code), 10)
+	}
+	metrics.ObserveDBQuery(q.repository, operation, duration, label)
+
+	if threshold := time.Duration(slowQueryThreshold.Load()); threshold > 0 && duration >= threshold {
+		logger.WarnContext(ctx, "slow query",
+			zap.String("repository", q.repository),
+			zap.String("operation", operation),
+			zap.Duration("duration", duration),
+			zap.Duration("threshold", threshold),
+			zap.String("query", normalizeQuery(query)),
+			zap.Int("args", args),
+			zap.Bool("failed", err != nil),
+		)
+	}
+
+	if err == nil {
+		return nil
+	}
+	return sqlerr.Translate(err, code, "%s %s", q.repository, operation)
+}
+
+// queryErrorCode returns the pkg/errors/sql code of a failed query, from the error when sqlerr.CodeOf knows it
+// and from the statement otherwise
+func queryErrorCode(operation, query string, err error) entity.Code {
+	return sqlerr.CodeOf(err, statementErrorCode(operation, query))
+}
+
+// statementErrorCode returns the code of a failed statement whose error has no specific code
+func statementErrorCode(operation, query string) entity.Code {
+	if operation == "prepare" {
+		return sqlerr.CodeSQLPrepareStmt
+	}
+
+	verb, _, _ := strings.Cut(strings.TrimSpace(query), " ")
+	switch strings.ToUpper(verb) {
+	case "INSERT":
+		return sqlerr.CodeSQLCreate
+	case "UPDATE":
+		return sqlerr.CodeSQLUpdate
+	case "DELETE":
+		return sqlerr.CodeSQLDelete
+	case "TRUNCATE":
+		return sqlerr.CodeSQLTruncate
+	default:
+		return sqlerr.CodeSQLRead
+	}
+}
+
+// normalizeQuery collapses whitespace and replaces string and number literals with ?
+func normalizeQuery(query string) string {
+	query = stringLiteralRegexp.ReplaceAllString(query, "?")
+	query = numberLiteralRegexp.ReplaceAllString(query, "${1}?")
+	query = strings.TrimSpace(whitespaceRegexp.ReplaceAllString(query, " "))
+	if len(query) > maxLoggedQueryLength {
+		query = query[:maxLoggedQueryLength] + "..."
+	}
+	return query
+}
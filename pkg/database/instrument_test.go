@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-skeleton/pkg/errors/entity"
+	sqlerr "go-skeleton/pkg/errors/sql"
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeQuerier records the queries it receives, the methods it does not override panic
+type fakeQuerier struct {
+	Querier
+	queries []string
+	err     error
+}
+
+func (f *fakeQuerier) ExecContext(_ context.Context, query string, _ ...any) (sql.Result, error) {
+	f.queries = append(f.queries, query)
+	return nil, f.err
+}
+
+func (f *fakeQuerier) GetContext(_ context.Context, _ any, query string, _ ...any) error {
+	f.queries = append(f.queries, query)
+	return f.err
+}
+
+func TestInstrument_PassesThrough(t *testing.T) {
+	fake := &fakeQuerier{err: sql.ErrNoRows}
+	q := Instrument(fake, "test_repo")
+
+	_, execErr := q.ExecContext(context.Background(), "DELETE FROM orders WHERE id = $1", 1)
+	getErr := q.GetContext(context.Background(), nil, "SELECT * FROM orders WHERE id = $1", 1)
+
+	assert.Equal(t, []string{"DELETE FROM orders WHERE id = $1", "SELECT * FROM orders WHERE id = $1"}, fake.queries)
+	assert.Equal(t, sqlerr.CodeSQLRecordDoesNotExist, entity.ErrCode(execErr))
+	assert.Equal(t, sqlerr.CodeSQLRecordDoesNotExist, entity.ErrCode(getErr))
+	assert.Equal(t, sql.ErrNoRows, entity.RootCause(getErr))
+}
+
+func TestInstrument_TranslatesPQErrors(t *testing.T) {
+	fake := &fakeQuerier{err: &pq.Error{Code: "23505", Constraint: "orders_number_key", Table: "orders"}}
+	q := Instrument(fake, "orders_repo")
+
+	_, err := q.ExecContext(context.Background(), "INSERT INTO orders (number) VALUES ($1)", "A-1")
+
+	assert.Equal(t, sqlerr.CodeSQLUniqueConstraint, entity.ErrCode(err))
+	assert.Contains(t, err.Error(), "orders_repo exec: unique_violation (constraint orders_number_key on orders)")
+}
+
+func TestQueryErrorCode(t *testing.T) {
+	failure := errors.New("failure")
+	tests := []struct {
+		name      string
+		operation string
+		query     string
+		err       error
+		expected  uint16
+	}{
+		{"no rows", "get", "SELECT * FROM orders", sql.ErrNoRows, uint16(sqlerr.CodeSQLRecordDoesNotExist)},
+		{"prepare", "prepare", "INSERT INTO orders VALUES ($1)", failure, uint16(sqlerr.CodeSQLPrepareStmt)},
+		{"insert", "exec", "  insert INTO orders VALUES ($1)", failure, uint16(sqlerr.CodeSQLCreate)},
+		{"update", "exec", "UPDATE orders SET paid = true", failure, uint16(sqlerr.CodeSQLUpdate)},
+		{"delete", "exec", "DELETE FROM orders", failure, uint16(sqlerr.CodeSQLDelete)},
+		{"truncate", "exec", "TRUNCATE orders", failure, uint16(sqlerr.CodeSQLTruncate)},
+		{"select", "select", "SELECT * FROM orders", failure, uint16(sqlerr.CodeSQLRead)},
+		{"postgres error", "exec", "INSERT INTO orders VALUES ($1)", &pq.Error{Code: "23503"}, uint16(sqlerr.CodeSQLForeignKeyMissing)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, uint16(queryErrorCode(tt.operation, tt.query, tt.err)))
+		})
+	}
+}
+
+func TestNormalizeQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{"placeholders kept", "SELECT * FROM orders WHERE id = $1", "SELECT * FROM orders WHERE id = $1"},
+		{"string literal", "SELECT * FROM users WHERE email = 'a@b.c' AND name = 'O''Brien'", "SELECT * FROM users WHERE email = ? AND name = ?"},
+		{"number literal", "SELECT * FROM orders WHERE total > 10.5 LIMIT 20", "SELECT * FROM orders WHERE total > ? LIMIT ?"},
+		{"identifier with digits", "SELECT col1 FROM table2", "SELECT col1 FROM table2"},
+		{"whitespace", "SELECT *\n\tFROM   orders ", "SELECT * FROM orders"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, normalizeQuery(tt.query))
+		})
+	}
+}
+
+func TestNormalizeQuery_Truncates(t *testing.T) {
+	query := normalizeQuery("SELECT " + strings.Repeat("x", 2*maxLoggedQueryLength))
+
+	assert.Len(t, query, maxLoggedQueryLength+len("..."))
+}
@@ -1,26 +1,58 @@
 package database
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file" // file source driver
+	"github.com/golang-migrate/migrate/v4/source"
 )
 
+// DefaultMigrationsDir is where new migrations are created when MIGRATION_DIR is not set
+const DefaultMigrationsDir = "migrations"
+
 // MigrationManager handles database migrations
 type MigrationManager struct {
+	// Directory is where CreateMigration writes new migration files
 	Directory string
+	// Source holds the migrations that are applied
+	Source fs.FS
+}
+
+// MigrationFile is a migration found in the source
+type MigrationFile struct {
+	Version uint
+	Name    string
+	// Go is set for migrations registered with RegisterGoMigration
+	Go bool
+}
+
+// MigrationStatus is the migration state of the database
+type MigrationStatus struct {
+	// Version is the last applied migration, only meaningful when Applied is true
+	Version uint
+	Applied bool
+	// Dirty is set when a migration failed halfway, it must be fixed by hand and the version forced
+	Dirty   bool
+	Pending []MigrationFile
 }
 
-// NewMigrationManager creates a new migration manager
-func NewMigrationManager(directory string) *MigrationManager {
+// NewMigrationManager creates a new migration manager applying the migrations of source,
+// or of directory when source is nil
+func NewMigrationManager(directory string, source fs.FS) *MigrationManager {
+	if source == nil {
+		source = os.DirFS(directory)
+	}
 	return &MigrationManager{
 		Directory: directory,
+		Source:    source,
 	}
 }
 
@@ -54,8 +86,53 @@ func (mm *MigrationManager) CreateMigration(name string) error {
 	return nil
 }
 
-// getMigrate creates a new migrate instance
-func (mm *MigrationManager) getMigrate() (*migrate.Migrate, error) {
+// CreateGoMigration creates a Go migration stub with the given name, it registers itself when the migrations
+// package is compiled into the binary
+func (mm *MigrationManager) CreateGoMigration(name string) error {
+	if err := os.MkdirAll(mm.Directory, 0755); err != nil {
+		return fmt.Errorf("failed to create migrations directory: %w", err)
+	}
+
+	timestamp := time.Now().Format("20060102150405")
+	fileName := fmt.Sprintf("%s_%s.go", timestamp, name)
+	filePath := filepath.Join(mm.Directory, fileName)
+
+	stub := fmt.Sprintf(goMigrationStub, filepath.Base(mm.Directory), timestamp, name)
+	if err := os.WriteFile(filePath, []byte(stub), 0600); err != nil {
+		return fmt.Errorf("failed to create go migration file: %w", err)
+	}
+
+	fmt.Printf("Created migration file:\n  %s\n", filePath)
+	return nil
+}
+
+// goMigrationStub is the template of CreateGoMigration, filled with the package name, the version and the name
+const goMigrationStub = `package %[1]s
+
+import (
+	"context"
+	"go-skeleton/pkg/database"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func init() {
+	database.RegisterGoMigration(%[2]s, %[3]q, up%[2]s, down%[2]s)
+}
+
+func up%[2]s(ctx context.Context, tx *sqlx.Tx) error {
+	// Write your UP migration here, database.BatchExec backfills large tables in batches
+	return nil
+}
+
+func down%[2]s(ctx context.Context, tx *sqlx.Tx) error {
+	// Write your DOWN migration here
+	return nil
+}
+`
+
+// getMigrate creates a new migrate instance whose Go migrations run with ctx
+func (mm *MigrationManager) getMigrate(ctx context.Context) (*migrate.Migrate, error) {
 	// Create a new postgres driver
 	driver, err := postgres.WithInstance(MigrationDB.DB, &postgres.Config{
 		MigrationsTable: "schema_migrations",
@@ -64,9 +141,13 @@ func (mm *MigrationManager) getMigrate() (*migrate.Migrate, error) {
 		return nil, fmt.Errorf("failed to create postgres driver: %w", err)
 	}
 
-	// Create a new migrate instance
-	sourceURL := fmt.Sprintf("file://%s", mm.Directory)
-	m, err := migrate.NewWithDatabaseInstance(sourceURL, "postgres", driver)
+	// Create a new migrate instance, Go migrations run in a transaction of the migration connection
+	sourceDriver, err := newMigrationSource(mm.Source, goMigrations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	goDriver := &goMigrationDriver{Driver: driver, ctx: ctx, db: MigrationDB, goMigrations: goMigrations}
+	m, err := migrate.NewWithInstance("migrations", sourceDriver, "postgres", goDriver)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create migrate instance: %w", err)
 	}
@@ -76,7 +157,7 @@ func (mm *MigrationManager) getMigrate() (*migrate.Migrate, error) {
 
 // ApplyMigrations applies all migrations
 func (mm *MigrationManager) ApplyMigrations() error {
-	m, err := mm.getMigrate()
+	m, err := mm.getMigrate(context.Background())
 	if err != nil {
 		return err
 	}
@@ -99,7 +180,7 @@ func (mm *MigrationManager) ApplyMigrations() error {
 
 // ApplyMigrationsSteps applies a specific number of migrations
 func (mm *MigrationManager) ApplyMigrationsSteps(steps int) error {
-	m, err := mm.getMigrate()
+	m, err := mm.getMigrate(context.Background())
 	if err != nil {
 		return err
 	}
@@ -124,7 +205,7 @@ func (mm *MigrationManager) RollbackMigration() error {
 
 // RollbackMigrationsSteps rolls back a specific number of migrations
 func (mm *MigrationManager) RollbackMigrationsSteps(steps int) error {
-	m, err := mm.getMigrate()
+	m, err := mm.getMigrate(context.Background())
 	if err != nil {
 		return err
 	}
@@ -145,7 +226,7 @@ func (mm *MigrationManager) RollbackMigrationsSteps(steps int) error {
 
 // RollbackAllMigrations rolls back all applied migrations
 func (mm *MigrationManager) RollbackAllMigrations() error {
-	m, err := mm.getMigrate()
+	m, err := mm.getMigrate(context.Background())
 	if err != nil {
 		return err
 	}
@@ -166,7 +247,7 @@ func (mm *MigrationManager) RollbackAllMigrations() error {
 
 // MigrateTo migrates to a specific version
 func (mm *MigrationManager) MigrateTo(version uint) error {
-	m, err := mm.getMigrate()
+	m, err := mm.getMigrate(context.Background())
 	if err != nil {
 		return err
 	}
@@ -187,7 +268,7 @@ func (mm *MigrationManager) MigrateTo(version uint) error {
 
 // GetCurrentVersion returns the current migration version
 func (mm *MigrationManager) GetCurrentVersion() (uint, bool, error) {
-	m, err := mm.getMigrate()
+	m, err := mm.getMigrate(context.Background())
 	if err != nil {
 		return 0, false, err
 	}
@@ -196,3 +277,74 @@ func (mm *MigrationManager) GetCurrentVersion() (uint, bool, error) {
 
 	return m.Version()
 }
+
+// Force sets the version without running any migration and clears the dirty flag, -1 means no migration applied
+func (mm *MigrationManager) Force(version int) error {
+	m, err := mm.getMigrate(context.Background())
+	if err != nil {
+		return err
+	}
+	// Don't close migrate instance to avoid affecting main Migration connection
+	// defer m.Close()
+
+	if err := m.Force(version); err != nil {
+		return fmt.Errorf("failed to force version %d: %w", version, err)
+	}
+
+	// Close the migration database connection
+	CloseMigrationDB()
+
+	fmt.Printf("Forced version %d successfully\n", version)
+	return nil
+}
+
+// Status returns the current version, the dirty flag and the migrations not applied yet
+func (mm *MigrationManager) Status() (MigrationStatus, error) {
+	files, err := mm.Migrations()
+	if err != nil {
+		return MigrationStatus{}, err
+	}
+
+	version, dirty, err := mm.GetCurrentVersion()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return MigrationStatus{}, fmt.Errorf("failed to read current version: %w", err)
+	}
+
+	// Close the migration database connection
+	CloseMigrationDB()
+
+	status := MigrationStatus{Version: version, Applied: err == nil, Dirty: dirty}
+	for _, file := range files {
+		if !status.Applied || file.Version > version {
+			status.Pending = append(status.Pending, file)
+		}
+	}
+	return status, nil
+}
+
+// Migrations lists the migrations of the source and the Go migrations ordered by version, files that are not
+// migrations are ignored
+func (mm *MigrationManager) Migrations() ([]MigrationFile, error) {
+	entries, err := fs.ReadDir(mm.Source, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	var files []MigrationFile
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		migration, err := source.DefaultParse(entry.Name())
+		if err != nil || migration.Direction != source.Up {
+			continue
+		}
+		files = append(files, MigrationFile{Version: migration.Version, Name: migration.Identifier})
+	}
+	for _, migration := range goMigrations {
+		files = append(files, MigrationFile{Version: migration.Version, Name: migration.Name, Go: true})
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Version < files[j].Version })
+	return files, nil
+}
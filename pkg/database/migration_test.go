@@ -0,0 +1,70 @@
+package database
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrationManager_Migrations(t *testing.T) {
+	source := fstest.MapFS{
+		"20240102000000_add_orders.up.sql":   {Data: []byte("CREATE TABLE orders ();")},
+		"20240102000000_add_orders.down.sql": {Data: []byte("DROP TABLE orders;")},
+		"20240101000000_add_users.up.sql":    {Data: []byte("CREATE TABLE users ();")},
+		"20240101000000_add_users.down.sql":  {Data: []byte("DROP TABLE users;")},
+		"README.md":                          {Data: []byte("not a migration")},
+	}
+	mm := NewMigrationManager(DefaultMigrationsDir, source)
+
+	files, err := mm.Migrations()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []MigrationFile{
+		{Version: 20240101000000, Name: "add_users"},
+		{Version: 20240102000000, Name: "add_orders"},
+	}, files)
+}
+
+func TestMigrationManager_Directory(t *testing.T) {
+	mm := NewMigrationManager(t.TempDir(), nil)
+
+	assert.NoError(t, mm.CreateMigration("add_orders"))
+
+	files, err := mm.Migrations()
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, "add_orders", files[0].Name)
+}
+
+func TestCheckSchema(t *testing.T) {
+	files := []MigrationFile{{Version: 1, Name: "add_users"}, {Version: 2, Name: "add_orders"}}
+
+	tests := []struct {
+		name    string
+		version uint
+		applied bool
+		dirty   bool
+		files   []MigrationFile
+		wantErr string
+	}{
+		{"empty database", 0, false, false, files, ""},
+		{"behind", 1, true, false, files, ""},
+		{"up to date", 2, true, false, files, ""},
+		{"dirty", 2, true, true, files, "dirty at version 2"},
+		{"ahead of the binary", 3, true, false, files, "newer than the latest migration 2"},
+		{"no known migrations", 1, true, false, nil, "newer than the latest migration 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSchema(tt.version, tt.applied, tt.dirty, tt.files)
+
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package database
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+)
+
+type pinKey struct{}
+
+// WithPinScope returns a copy of ctx in which PinPrimary lasts until the scope ends, usually one request
+func WithPinScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, pinKey{}, new(atomic.Bool))
+}
+
+// PinPrimary sends the remaining reads of the pin scope of ctx to the primary, it does nothing outside a scope
+func PinPrimary(ctx context.Context) {
+	if pinned, ok := ctx.Value(pinKey{}).(*atomic.Bool); ok {
+		pinned.Store(true)
+	}
+}
+
+// IsPinned reports whether the reads of the pin scope of ctx go to the primary
+func IsPinned(ctx context.Context) bool {
+	pinned, ok := ctx.Value(pinKey{}).(*atomic.Bool)
+	return ok && pinned.Load()
+}
+
+// PinScopeGinMiddleware opens a pin scope for every HTTP request
+func PinScopeGinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(WithPinScope(c.Request.Context()))
+		c.Next()
+	}
+}
+
+// PinScopeUnaryServerInterceptor opens a pin scope for every gRPC call
+func PinScopeUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(WithPinScope(ctx), req)
+	}
+}
@@ -0,0 +1,29 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Querier runs queries on a pool or inside a transaction, *sqlx.DB and *sqlx.Tx implement it. It leaves out
+// QueryRowxContext as a *sqlx.Row reports its error at Scan, past the instrumentation, single rows are read with
+// GetContext instead.
+type Querier interface {
+	sqlx.ExecerContext
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryxContext(ctx context.Context, query string, args ...any) (*sqlx.Rows, error)
+	DriverName() string
+	Rebind(query string) string
+	BindNamed(query string, arg any) (string, []any, error)
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+	NamedExecContext(ctx context.Context, query string, arg any) (sql.Result, error)
+	PreparexContext(ctx context.Context, query string) (*sqlx.Stmt, error)
+}
+
+var (
+	_ Querier = (*sqlx.DB)(nil)
+	_ Querier = (*sqlx.Tx)(nil)
+)
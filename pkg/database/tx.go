@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-skeleton/pkg/errors/entity"
+	sqlerr "go-skeleton/pkg/errors/sql"
+	"go-skeleton/pkg/logger"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// DefaultTxRetries is how many times a transaction is run again after a serialization failure or a deadlock
+const DefaultTxRetries = 3
+
+// txRetryDelay is the base delay before a transaction is run again, multiplied by the attempt
+const txRetryDelay = 20 * time.Millisecond
+
+// Postgres error codes of conflicts that succeed when the transaction is run again
+const (
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
+// TxOptions configures a transaction started by Transactor.WithinTx
+type TxOptions struct {
+	Isolation sql.IsolationLevel
+	ReadOnly  bool
+	// Retries is how many times fn runs again after a serialization failure or a deadlock
+	Retries int
+}
+
+// TxOption changes the TxOptions of a transaction
+type TxOption func(*TxOptions)
+
+// WithIsolation sets the isolation level, the server default is used otherwise
+func WithIsolation(level sql.IsolationLevel) TxOption {
+	return func(o *TxOptions) { o.Isolation = level }
+}
+
+// ReadOnly starts a read only transaction
+func ReadOnly() TxOption {
+	return func(o *TxOptions) { o.ReadOnly = true }
+}
+
+// WithRetries sets how many times fn runs again after a serialization failure or a deadlock
+func WithRetries(retries int) TxOption {
+	return func(o *TxOptions) { o.Retries = retries }
+}
+
+type txKey struct{}
+
+// txState is the transaction stored in the context and how deeply WithinTx calls are nested in it
+type txState struct {
+	tx    *sqlx.Tx
+	depth int
+}
+
+// TxFromContext returns the transaction WithinTx stored in ctx
+func TxFromContext(ctx context.Context) (*sqlx.Tx, bool) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		return nil, false
+	}
+	return state.tx, true
+}
+
+// Transactor runs functions inside transactions of the primary
+type Transactor struct {
+	db *sqlx.DB
+}
+
+// NewTransactor returns a transactor whose transactions run on the primary of cluster
+func NewTransactor(cluster *Cluster) *Transactor {
+	return &Transactor{db: cluster.Primary()}
+}
+
+// WithinTx runs fn inside a transaction stored in the context passed to fn, so repositories using Cluster.Reader
+// or Cluster.Writer join it. The transaction is committed when fn returns nil and rolled back otherwise.
+// A WithinTx call inside a transaction runs fn in a savepoint of it, its options are ignored.
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) error {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return withinSavepoint(ctx, state, fn)
+	}
+
+	options := TxOptions{Retries: DefaultTxRetries}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := t.run(ctx, options, fn)
+		if err == nil || !isRetryable(err) {
+			return err
+		}
+		if attempt >= options.Retries {
+			// The conflict keeps its code, CodeSQLConflict unless fn attached another one
+			return sqlerr.Translate(err, sqlerr.CodeSQLConflict, "transaction failed after %d attempt(s)", attempt+1)
+		}
+
+		logger.WarnContext(ctx, "transaction conflict, retrying", zap.Int("attempt", attempt+1), zap.Error(err))
+		wait := time.Duration(attempt+1) * txRetryDelay
+		select {
+		case <-ctx.Done():
+			return sqlerr.Translate(ctx.Err(), sqlerr.CodeSQLTransactionFailed, "transaction retry canceled")
+		case <-time.After(wait + time.Duration(rand.Int64N(int64(wait)))):
+		}
+	}
+}
+
+// run executes fn once in a new transaction
+func (t *Transactor) run(ctx context.Context, options TxOptions, fn func(ctx context.Context) error) (err error) {
+	tx, err := t.db.BeginTxx(ctx, &sql.TxOptions{Isolation: options.Isolation, ReadOnly: options.ReadOnly})
+	if err != nil {
+		return entity.WrapWithCode(err, sqlerr.CodeSQLTxBegin, "begin transaction")
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	PinPrimary(ctx)
+	if err := fn(context.WithValue(ctx, txKey{}, &txState{tx: tx})); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return entity.WrapWithCode(errors.Join(err, rollbackErr), sqlerr.CodeSQLTxRollback, "rollback transaction")
+		}
+		return err
+	}
+
+	// Serialization failures reported at commit are translated to CodeSQLConflict like those of queries
+	if err := tx.Commit(); err != nil {
+		return sqlerr.Translate(err, sqlerr.CodeSQLTxCommit, "commit transaction")
+	}
+	return nil
+}
+
+// withinSavepoint runs fn in a savepoint of the transaction of state, only the work of fn is rolled back on failure
+func withinSavepoint(ctx context.Context, state *txState, fn func(ctx context.Context) error) (err error) {
+	nested := &txState{tx: state.tx, depth: state.depth + 1}
+	savepoint := fmt.Sprintf("sp_%d", nested.depth)
+
+	if _, err := state.tx.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+		return entity.WrapWithCode(err, sqlerr.CodeSQLTxBegin, "create savepoint %s", savepoint)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_, _ = state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+savepoint)
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, nested)); err != nil {
+		if _, rollbackErr := state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+savepoint); rollbackErr != nil {
+			return entity.WrapWithCode(errors.Join(err, rollbackErr), sqlerr.CodeSQLTxRollback, "rollback to savepoint %s", savepoint)
+		}
+		return err
+	}
+
+	if _, err := state.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+savepoint); err != nil {
+		return entity.WrapWithCode(err, sqlerr.CodeSQLTxCommit, "release savepoint %s", savepoint)
+	}
+	return nil
+}
+
+// isRetryable reports whether err comes from a serialization failure or a deadlock
+func isRetryable(err error) bool {
+	pqErr, ok := sqlerr.AsPQError(err)
+	if !ok {
+		return false
+	}
+	return pqErr.Code == pgSerializationFailure || pqErr.Code == pgDeadlockDetected
+}
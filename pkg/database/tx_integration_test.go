@@ -0,0 +1,120 @@
+//go:build integration
+// +build integration
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-skeleton/config"
+	"go-skeleton/pkg/errors/entity"
+	sqlerr "go-skeleton/pkg/errors/sql"
+	"os"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTransactor(t *testing.T) (*Transactor, *Cluster) {
+	t.Helper()
+	if os.Getenv("INTEGRATION_TEST") != "true" {
+		t.Skip("Skipping integration test. Set INTEGRATION_TEST=true to run")
+	}
+
+	config.InitForTest()
+	if DBCluster == nil {
+		Init(config.Database, config.Startup)
+	}
+
+	ctx := context.Background()
+	_, err := DBCluster.Primary().ExecContext(ctx, "CREATE TABLE IF NOT EXISTS tx_test (id INT PRIMARY KEY)")
+	assert.NoError(t, err)
+	_, err = DBCluster.Primary().ExecContext(ctx, "TRUNCATE tx_test")
+	assert.NoError(t, err)
+
+	return NewTransactor(DBCluster), DBCluster
+}
+
+func countRows(t *testing.T, cluster *Cluster) int {
+	t.Helper()
+
+	var count int
+	assert.NoError(t, cluster.Primary().GetContext(context.Background(), &count, "SELECT COUNT(*) FROM tx_test"))
+	return count
+}
+
+func TestWithinTx_CommitAndRollback(t *testing.T) {
+	transactor, cluster := setupTransactor(t)
+	ctx := context.Background()
+
+	err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+		_, err := cluster.Writer(ctx).ExecContext(ctx, "INSERT INTO tx_test (id) VALUES (1)")
+		return err
+	})
+	assert.NoError(t, err)
+
+	failure := errors.New("failure")
+	err = transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := cluster.Writer(ctx).ExecContext(ctx, "INSERT INTO tx_test (id) VALUES (2)"); err != nil {
+			return err
+		}
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, 1, countRows(t, cluster))
+}
+
+func TestWithinTx_NestedSavepoint(t *testing.T) {
+	transactor, cluster := setupTransactor(t)
+	ctx := context.Background()
+
+	err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := cluster.Writer(ctx).ExecContext(ctx, "INSERT INTO tx_test (id) VALUES (1)"); err != nil {
+			return err
+		}
+
+		nestedErr := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			if _, err := cluster.Writer(ctx).ExecContext(ctx, "INSERT INTO tx_test (id) VALUES (2)"); err != nil {
+				return err
+			}
+			return errors.New("undo the nested insert")
+		})
+		assert.Error(t, nestedErr)
+
+		var count int
+		assert.NoError(t, cluster.Reader(ctx).GetContext(ctx, &count, "SELECT COUNT(*) FROM tx_test"))
+		assert.Equal(t, 1, count)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, countRows(t, cluster))
+}
+
+func TestWithinTx_ReadOnly(t *testing.T) {
+	transactor, cluster := setupTransactor(t)
+
+	err := transactor.WithinTx(context.Background(), func(ctx context.Context) error {
+		_, err := cluster.Writer(ctx).ExecContext(ctx, "INSERT INTO tx_test (id) VALUES (1)")
+		return err
+	}, ReadOnly(), WithIsolation(sql.LevelSerializable))
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, countRows(t, cluster))
+}
+
+func TestWithinTx_RetriesSerializationFailures(t *testing.T) {
+	transactor, _ := setupTransactor(t)
+	attempts := 0
+
+	err := transactor.WithinTx(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return &pq.Error{Code: pgSerializationFailure}
+	}, WithRetries(2))
+
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, sqlerr.CodeSQLConflict, entity.ErrCode(err))
+	assert.Contains(t, err.Error(), "transaction failed after 3 attempt(s)")
+}
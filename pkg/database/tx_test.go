@@ -0,0 +1,38 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"go-skeleton/pkg/errors/entity"
+	sqlerr "go-skeleton/pkg/errors/sql"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"serialization failure", &pq.Error{Code: pgSerializationFailure}, true},
+		{"deadlock", &pq.Error{Code: pgDeadlockDetected}, true},
+		{"wrapped", entity.WrapWithCode(&pq.Error{Code: pgSerializationFailure}, sqlerr.CodeSQLTxCommit, "commit transaction"), true},
+		{"unique violation", &pq.Error{Code: "23505"}, false},
+		{"other error", errors.New("connection refused"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isRetryable(tt.err))
+		})
+	}
+}
+
+func TestTxFromContext(t *testing.T) {
+	_, ok := TxFromContext(context.Background())
+
+	assert.False(t, ok)
+}
@@ -64,6 +64,16 @@ var (
 		EN:         `A record with this information already exists. Please use different data or contact support.`,
 		ID:         `Data dengan informasi ini sudah ada. Silakan gunakan data yang berbeda atau hubungi dukungan.`,
 	}
+	ErrMsgReferenceConflict = Message{
+		StatusCode: http.StatusConflict,
+		EN:         `This record refers to data that does not exist or is still used by other data. Please check the related data and try again.`,
+		ID:         `Data ini merujuk ke data yang tidak ada atau masih digunakan oleh data lain. Silakan periksa data terkait dan coba lagi.`,
+	}
+	ErrMsgConcurrentUpdate = Message{
+		StatusCode: http.StatusConflict,
+		EN:         `The data was changed by another request at the same time. Please try again.`,
+		ID:         `Data diubah oleh permintaan lain pada saat yang sama. Silakan coba lagi.`,
+	}
 	ErrMsgServiceUnavailable = Message{
 		StatusCode: http.StatusServiceUnavailable,
 		EN:         `Service is temporarily unavailable. Please try again later.`,
@@ -7,6 +7,7 @@ import (
 	"go-skeleton/pkg/errors/general"
 	httperr "go-skeleton/pkg/errors/http"
 	"go-skeleton/pkg/errors/sql"
+	"go-skeleton/pkg/redact"
 	"net/http"
 	"strings"
 
@@ -61,10 +62,10 @@ func (e *AppError) Error() string {
 // Compile - Get Error Code and HTTP Status
 // Common --> Service --> Default
 func Compile(service ServiceType, err error, lang string, debugMode bool) (int, AppError) {
-	// Developer Debug Error
+	// Developer Debug Error, redacted because raw errors may carry DSNs, queries and tokens
 	var debugErr *string
 	if debugMode {
-		errStr := err.Error()
+		errStr := redact.String(err.Error())
 		if len(errStr) > 0 {
 			debugErr = &errStr
 		}
@@ -1,9 +1,12 @@
 package errors
 
 import (
+	stdsql "database/sql"
 	"errors"
+	"go-skeleton/pkg/errors/sql"
 	"testing"
 
+	"github.com/lib/pq"
 	"github.com/palantir/stacktrace"
 	"github.com/stretchr/testify/assert"
 )
@@ -50,6 +53,16 @@ func TestCompile_WithDebugMode(t *testing.T) {
 	assert.Contains(t, *appErr.DebugError, "debug error")
 }
 
+func TestCompile_WithDebugMode_Redacted(t *testing.T) {
+	testErr := errors.New("connect postgres://app:s3cret@db:5432/app failed")
+
+	_, appErr := Compile(COMMON, testErr, "en", true)
+
+	assert.NotNil(t, appErr.DebugError)
+	assert.NotContains(t, *appErr.DebugError, "s3cret")
+	assert.Contains(t, *appErr.DebugError, "[REDACTED]")
+}
+
 func TestCompile_WithoutDebugMode(t *testing.T) {
 	testErr := errors.New("hidden error")
 
@@ -76,3 +89,24 @@ func TestValidationError_Struct(t *testing.T) {
 	assert.Equal(t, "email", validationErr.Field)
 	assert.Equal(t, "Invalid email format", validationErr.Message)
 }
+
+func TestCompile_TranslatedSQLErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"no rows", sql.Translate(stdsql.ErrNoRows, sql.CodeSQLRead, "get order"), 404},
+		{"unique violation", sql.Translate(&pq.Error{Code: "23505"}, sql.CodeSQLCreate, "create order"), 409},
+		{"foreign key violation", sql.Translate(&pq.Error{Code: "23503"}, sql.CodeSQLCreate, "create order"), 409},
+		{"other error", sql.Translate(errors.New("bad connection"), sql.CodeSQLRead, "get order"), 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statusCode, _ := Compile(SQL, tt.err, "en", false)
+
+			assert.Equal(t, tt.expected, statusCode)
+		})
+	}
+}
@@ -29,4 +29,10 @@ const (
 	CodeSQLTransactionFailed
 	CodeSQLPing
 	CodeSQLTruncate
+	CodeSQLNotNullViolation
+	CodeSQLCheckViolation
+	CodeSQLInvalidValue
+	CodeSQLConflict
+	CodeSQLQueryCanceled
+	CodeSQLUnavailable
 )
@@ -25,7 +25,13 @@ var ErrorMessages = errors.ErrorMessage{
 	CodeSQLRecordIsExpired:            errors.ErrMsgBadRequest,
 	CodeSQLPing:                       errors.ErrMsgBadRequestCustom,
 	CodeSQLRecordDoesNotExist:         errors.ErrMsgNotFound,
-	CodeSQLForeignKeyMissing:          errors.ErrMsgISE,
+	CodeSQLForeignKeyMissing:          errors.ErrMsgReferenceConflict,
 	CodeSQLTransactionFailed:          errors.ErrMsgISE,
 	CodeSQLTruncate:                   errors.ErrMsgISE,
+	CodeSQLNotNullViolation:           errors.ErrMsgBadRequest,
+	CodeSQLCheckViolation:             errors.ErrMsgBadRequest,
+	CodeSQLInvalidValue:               errors.ErrMsgBadRequest,
+	CodeSQLConflict:                   errors.ErrMsgConcurrentUpdate,
+	CodeSQLQueryCanceled:              errors.ErrMsgContextTimeout,
+	CodeSQLUnavailable:                errors.ErrMsgServiceUnavailable,
 }
@@ -0,0 +1,130 @@
+package sql
+
+import (
+	"context"
+	stdsql "database/sql"
+	"errors"
+	"fmt"
+	"go-skeleton/pkg/errors/entity"
+	"go-skeleton/pkg/errors/general"
+
+	"github.com/lib/pq"
+	"github.com/palantir/stacktrace"
+)
+
+// Postgres SQLSTATE codes translated to a specific code, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgNotNullViolation     = "23502"
+	pgForeignKeyViolation  = "23503"
+	pgUniqueViolation      = "23505"
+	pgCheckViolation       = "23514"
+	pgExclusionViolation   = "23P01"
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+	pgQueryCanceled        = "57014"
+	pgAdminShutdown        = "57P01"
+	pgCrashShutdown        = "57P02"
+	pgCannotConnectNow     = "57P03"
+	pgTooManyConnections   = "53300"
+)
+
+// Postgres SQLSTATE classes translated to a specific code
+const (
+	pgClassConnectionException pq.ErrorClass = "08"
+	pgClassDataException       pq.ErrorClass = "22"
+)
+
+// Translate wraps err with the code matching its Postgres SQLSTATE, sql.ErrNoRows or context error, and with
+// fallback otherwise. The constraint, column or table reported by Postgres is appended to the message.
+// Errors that already carry a code keep it.
+func Translate(err error, fallback entity.Code, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+	if entity.ErrCode(err) != stacktrace.NoCode {
+		return entity.Wrap(err, format, args...)
+	}
+
+	msg := fmt.Sprintf(format, args...)
+	if pqErr, ok := AsPQError(err); ok {
+		if annotation := annotate(pqErr); annotation != "" {
+			msg += ": " + annotation
+		}
+	}
+	return entity.WrapWithCode(err, CodeOf(err, fallback), "%s", msg)
+}
+
+// CodeOf returns the code Translate attaches to err
+func CodeOf(err error, fallback entity.Code) entity.Code {
+	if code := entity.ErrCode(err); code != stacktrace.NoCode {
+		return code
+	}
+
+	if pqErr, ok := AsPQError(err); ok {
+		if code, ok := pqCode(pqErr.Code); ok {
+			return code
+		}
+		return fallback
+	}
+
+	cause := entity.RootCause(err)
+	switch {
+	case errors.Is(err, stdsql.ErrNoRows) || errors.Is(cause, stdsql.ErrNoRows):
+		return CodeSQLRecordDoesNotExist
+	case errors.Is(err, context.DeadlineExceeded) || errors.Is(cause, context.DeadlineExceeded):
+		return general.CodeContextDeadlineExceeded
+	case errors.Is(err, context.Canceled) || errors.Is(cause, context.Canceled):
+		return general.CodeContextCanceled
+	}
+	return fallback
+}
+
+// AsPQError returns the Postgres error err wraps, through stacktrace wrapping as well
+func AsPQError(err error) (*pq.Error, bool) {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) || errors.As(entity.RootCause(err), &pqErr) {
+		return pqErr, true
+	}
+	return nil, false
+}
+
+// pqCode maps a SQLSTATE to a code, ok is false when the state has no specific code
+func pqCode(state pq.ErrorCode) (entity.Code, bool) {
+	switch state {
+	case pgUniqueViolation, pgExclusionViolation:
+		return CodeSQLUniqueConstraint, true
+	case pgForeignKeyViolation:
+		return CodeSQLForeignKeyMissing, true
+	case pgNotNullViolation:
+		return CodeSQLNotNullViolation, true
+	case pgCheckViolation:
+		return CodeSQLCheckViolation, true
+	case pgSerializationFailure, pgDeadlockDetected:
+		return CodeSQLConflict, true
+	case pgQueryCanceled:
+		return CodeSQLQueryCanceled, true
+	case pgAdminShutdown, pgCrashShutdown, pgCannotConnectNow, pgTooManyConnections:
+		return CodeSQLUnavailable, true
+	}
+
+	switch state.Class() {
+	case pgClassDataException:
+		return CodeSQLInvalidValue, true
+	case pgClassConnectionException:
+		return CodeSQLUnavailable, true
+	}
+	return 0, false
+}
+
+// annotate describes the object a Postgres error is about, values from the detail are left out
+func annotate(pqErr *pq.Error) string {
+	switch {
+	case pqErr.Constraint != "":
+		return fmt.Sprintf("%s (constraint %s on %s)", pqErr.Code.Name(), pqErr.Constraint, pqErr.Table)
+	case pqErr.Column != "":
+		return fmt.Sprintf("%s (column %s on %s)", pqErr.Code.Name(), pqErr.Column, pqErr.Table)
+	case pqErr.Table != "":
+		return fmt.Sprintf("%s (table %s)", pqErr.Code.Name(), pqErr.Table)
+	}
+	return pqErr.Code.Name()
+}
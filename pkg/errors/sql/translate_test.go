@@ -0,0 +1,78 @@
+package sql
+
+import (
+	"context"
+	stdsql "database/sql"
+	"errors"
+	"go-skeleton/pkg/errors/entity"
+	"go-skeleton/pkg/errors/general"
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected entity.Code
+	}{
+		{"unique violation", &pq.Error{Code: "23505"}, CodeSQLUniqueConstraint},
+		{"exclusion violation", &pq.Error{Code: "23P01"}, CodeSQLUniqueConstraint},
+		{"foreign key violation", &pq.Error{Code: "23503"}, CodeSQLForeignKeyMissing},
+		{"not null violation", &pq.Error{Code: "23502"}, CodeSQLNotNullViolation},
+		{"check violation", &pq.Error{Code: "23514"}, CodeSQLCheckViolation},
+		{"invalid text representation", &pq.Error{Code: "22P02"}, CodeSQLInvalidValue},
+		{"serialization failure", &pq.Error{Code: "40001"}, CodeSQLConflict},
+		{"deadlock", &pq.Error{Code: "40P01"}, CodeSQLConflict},
+		{"statement timeout", &pq.Error{Code: "57014"}, CodeSQLQueryCanceled},
+		{"too many connections", &pq.Error{Code: "53300"}, CodeSQLUnavailable},
+		{"connection failure", &pq.Error{Code: "08006"}, CodeSQLUnavailable},
+		{"syntax error", &pq.Error{Code: "42601"}, CodeSQLRead},
+		{"wrapped postgres error", entity.Wrap(&pq.Error{Code: "23505"}, "create order"), CodeSQLUniqueConstraint},
+		{"no rows", stdsql.ErrNoRows, CodeSQLRecordDoesNotExist},
+		{"wrapped no rows", entity.Wrap(stdsql.ErrNoRows, "get order"), CodeSQLRecordDoesNotExist},
+		{"deadline exceeded", context.DeadlineExceeded, general.CodeContextDeadlineExceeded},
+		{"canceled", context.Canceled, general.CodeContextCanceled},
+		{"existing code", entity.WrapWithCode(&pq.Error{Code: "23505"}, CodeSQLTxCommit, "commit"), CodeSQLTxCommit},
+		{"other error", errors.New("bad connection"), CodeSQLRead},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CodeOf(tt.err, CodeSQLRead))
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	pqErr := &pq.Error{Code: "23503", Constraint: "orders_customer_id_fkey", Table: "orders", Detail: "Key (customer_id)=(42) is not present"}
+
+	err := Translate(pqErr, CodeSQLCreate, "create order %s", "A-1")
+
+	assert.Equal(t, CodeSQLForeignKeyMissing, entity.ErrCode(err))
+	assert.Contains(t, err.Error(), "create order A-1: foreign_key_violation (constraint orders_customer_id_fkey on orders)")
+	assert.NotContains(t, strings.Split(err.Error(), "\n")[0], "42")
+	assert.Equal(t, pqErr, entity.RootCause(err))
+}
+
+func TestTranslate_Column(t *testing.T) {
+	err := Translate(&pq.Error{Code: "23502", Column: "email", Table: "users"}, CodeSQLCreate, "create user")
+
+	assert.Equal(t, CodeSQLNotNullViolation, entity.ErrCode(err))
+	assert.Contains(t, err.Error(), "create user: not_null_violation (column email on users)")
+}
+
+func TestTranslate_KeepsExistingCode(t *testing.T) {
+	inner := Translate(stdsql.ErrNoRows, CodeSQLRead, "orders_repo get")
+
+	err := Translate(inner, CodeSQLRead, "get order")
+
+	assert.Equal(t, CodeSQLRecordDoesNotExist, entity.ErrCode(err))
+}
+
+func TestTranslate_Nil(t *testing.T) {
+	assert.NoError(t, Translate(nil, CodeSQLRead, "get order"))
+}
@@ -0,0 +1,50 @@
+package healthcheck
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes registers the liveness, readiness and startup probe routes to the provided router
+func (r *Registry) RegisterRoutes(router *gin.Engine) {
+	group := router.Group("/healthz")
+	group.GET("/live", r.Live)
+	group.GET("/ready", r.Ready)
+	group.GET("/startup", r.Startup)
+}
+
+// Live reports that the process is running, dependency results are informational only
+func (r *Registry) Live(c *gin.Context) {
+	report := r.Last()
+	report.Status = StatusUp
+	c.JSON(http.StatusOK, report)
+}
+
+// Ready reports whether the application can serve traffic
+func (r *Registry) Ready(c *gin.Context) {
+	if r.ShuttingDown() {
+		c.JSON(http.StatusServiceUnavailable, r.Last())
+		return
+	}
+
+	respond(c, r.Check(c.Request.Context()))
+}
+
+// Startup reports whether the application finished starting and its dependencies are reachable
+func (r *Registry) Startup(c *gin.Context) {
+	report := r.Check(c.Request.Context())
+	if !r.Started() {
+		report.Status = StatusDown
+	}
+
+	respond(c, report)
+}
+
+func respond(c *gin.Context, report Report) {
+	statusCode := http.StatusOK
+	if report.Status != StatusUp {
+		statusCode = http.StatusServiceUnavailable
+	}
+	c.JSON(statusCode, report)
+}
@@ -0,0 +1,188 @@
+package healthcheck
+
+import (
+	"context"
+	"go-skeleton/pkg/logger"
+	"go-skeleton/pkg/redact"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+const defaultCheckTimeout = 2 * time.Second
+
+// CheckFunc reports whether a dependency is healthy, a nil error means healthy
+type CheckFunc func(ctx context.Context) error
+
+// Result holds the outcome of the latest run of a single dependency check, the error is redacted as it can hold
+// credentials of the dependency
+type Result struct {
+	Name        string     `json:"name"`
+	Status      Status     `json:"status"`
+	Latency     string     `json:"latency"`
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+	CheckedAt   time.Time  `json:"checked_at"`
+}
+
+// Report is the aggregated outcome of all dependency checks
+type Report struct {
+	Status       Status   `json:"status"`
+	ShuttingDown bool     `json:"shutting_down,omitempty"`
+	Checks       []Result `json:"checks"`
+}
+
+type check struct {
+	name   string
+	fn     CheckFunc
+	result Result
+}
+
+// Registry keeps the dependency checks and the lifecycle state used by the probes
+type Registry struct {
+	mu           sync.Mutex
+	checks       []*check
+	timeout      time.Duration
+	started      atomic.Bool
+	shuttingDown atomic.Bool
+}
+
+// NewRegistry creates a registry whose checks are bounded by the given timeout
+func NewRegistry(timeout time.Duration) *Registry {
+	if timeout <= 0 {
+		timeout = defaultCheckTimeout
+	}
+	return &Registry{
+		timeout: timeout,
+	}
+}
+
+// Register adds a named dependency check, registering an existing name replaces its check
+func (r *Registry) Register(name string, fn CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, c := range r.checks {
+		if c.name == name {
+			c.fn = fn
+			return
+		}
+	}
+	r.checks = append(r.checks, &check{
+		name:   name,
+		fn:     fn,
+		result: Result{Name: name, Status: StatusDown},
+	})
+}
+
+// MarkStarted records that the application finished starting up
+func (r *Registry) MarkStarted() {
+	r.started.Store(true)
+}
+
+// Started reports whether the application finished starting up
+func (r *Registry) Started() bool {
+	return r.started.Load()
+}
+
+// MarkShuttingDown flips readiness to failing so traffic is drained before the servers stop
+func (r *Registry) MarkShuttingDown() {
+	r.shuttingDown.Store(true)
+}
+
+// ShuttingDown reports whether a graceful shutdown is in progress
+func (r *Registry) ShuttingDown() bool {
+	return r.shuttingDown.Load()
+}
+
+// Check runs every registered check concurrently and returns the aggregated report
+func (r *Registry) Check(ctx context.Context) Report {
+	r.mu.Lock()
+	checks := make([]*check, len(r.checks))
+	copy(checks, r.checks)
+	r.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, c := range checks {
+		wg.Add(1)
+		go func(c *check) {
+			defer wg.Done()
+			r.run(ctx, c)
+		}(c)
+	}
+	wg.Wait()
+
+	return r.report(checks)
+}
+
+// Last returns the report from the latest results without running the checks again
+func (r *Registry) Last() Report {
+	r.mu.Lock()
+	checks := make([]*check, len(r.checks))
+	copy(checks, r.checks)
+	r.mu.Unlock()
+
+	return r.report(checks)
+}
+
+func (r *Registry) run(ctx context.Context, c *check) {
+	checkCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	r.mu.Lock()
+	fn := c.fn
+	r.mu.Unlock()
+
+	start := time.Now()
+	err := fn(checkCtx)
+	latency := time.Since(start)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c.result.CheckedAt = start
+	c.result.Latency = latency.String()
+	if err != nil {
+		// Log only when the check starts failing or the error changes, the probes run every few seconds
+		lastError := redact.String(err.Error())
+		if c.result.Status == StatusUp || c.result.LastError != lastError {
+			logger.Warn("health check failed", zap.String("check", c.name), zap.Error(err))
+		}
+		c.result.Status = StatusDown
+		c.result.LastError = lastError
+		c.result.LastErrorAt = &start
+		return
+	}
+	c.result.Status = StatusUp
+}
+
+func (r *Registry) report(checks []*check) Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := Report{
+		Status:       StatusUp,
+		ShuttingDown: r.ShuttingDown(),
+		Checks:       make([]Result, 0, len(checks)),
+	}
+	for _, c := range checks {
+		if c.result.Status != StatusUp {
+			report.Status = StatusDown
+		}
+		report.Checks = append(report.Checks, c.result)
+	}
+	if report.ShuttingDown {
+		report.Status = StatusDown
+	}
+
+	return report
+}
@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"context"
+	"go-skeleton/pkg/requestid"
+
+	"go.uber.org/zap"
+)
+
+type fieldsContextKey struct{}
+
+// NewContext returns a copy of ctx carrying additional request-scoped fields
+func NewContext(ctx context.Context, fields ...zap.Field) context.Context {
+	existing := contextStoredFields(ctx)
+	merged := make([]zap.Field, 0, len(existing)+len(fields))
+	merged = append(merged, existing...)
+	merged = append(merged, fields...)
+	return context.WithValue(ctx, fieldsContextKey{}, merged)
+}
+
+// WithUserID returns a copy of ctx whose logs carry the authenticated user ID
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return NewContext(ctx, zap.String("user_id", userID))
+}
+
+// WithModule returns a copy of ctx whose logs carry the module name
+func WithModule(ctx context.Context, module string) context.Context {
+	return NewContext(ctx, zap.String("module", module))
+}
+
+// FromContext returns the global logger enriched with the request-scoped fields of ctx
+func FromContext(ctx context.Context) *zap.Logger {
+	fields := ContextFields(ctx)
+	if len(fields) == 0 {
+		return GetLogger()
+	}
+	return GetLogger().With(fields...)
+}
+
+// WithContext returns the logger of ctx with the given fields added
+func WithContext(ctx context.Context, fields ...zap.Field) *zap.Logger {
+	return FromContext(ctx).With(fields...)
+}
+
+// ContextFields returns the request-scoped fields of ctx: request ID, trace/span IDs and fields added with NewContext
+func ContextFields(ctx context.Context) []zap.Field {
+	if ctx == nil {
+		return nil
+	}
+
+	var fields []zap.Field
+	if id := requestid.FromContext(ctx); id != "" {
+		fields = append(fields, zap.String("request_id", id))
+	}
+	fields = append(fields, TraceFields(ctx)...)
+	return append(fields, contextStoredFields(ctx)...)
+}
+
+func contextStoredFields(ctx context.Context) []zap.Field {
+	fields, _ := ctx.Value(fieldsContextKey{}).([]zap.Field)
+	return fields
+}
+
+// InfoContext logs at info level with the request-scoped fields of ctx
+func InfoContext(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).Info(msg, fields...)
+}
+
+// ErrorContext logs at error level with the request-scoped fields of ctx
+func ErrorContext(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).Error(msg, fields...)
+}
+
+// DebugContext logs at debug level with the request-scoped fields of ctx
+func DebugContext(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).Debug(msg, fields...)
+}
+
+// WarnContext logs at warn level with the request-scoped fields of ctx
+func WarnContext(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).Warn(msg, fields...)
+}
@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"context"
+	"go-skeleton/pkg/requestid"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func observeLogs(t *testing.T) *observer.ObservedLogs {
+	t.Helper()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	previous := globalLogger
+	globalLogger = zap.New(core)
+	t.Cleanup(func() {
+		globalLogger = previous
+	})
+
+	return logs
+}
+
+func TestFromContext(t *testing.T) {
+	logs := observeLogs(t)
+
+	ctx := requestid.NewContext(context.Background(), "abc-123")
+	ctx = WithModule(ctx, "ping")
+	ctx = WithUserID(ctx, "user-1")
+
+	FromContext(ctx).Info("hello")
+
+	entries := logs.All()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, map[string]any{
+		"request_id": "abc-123",
+		"module":     "ping",
+		"user_id":    "user-1",
+	}, entries[0].ContextMap())
+}
+
+func TestFromContext_WithoutFields(t *testing.T) {
+	logs := observeLogs(t)
+
+	FromContext(context.Background()).Info("hello")
+
+	assert.Len(t, logs.All(), 1)
+	assert.Empty(t, logs.All()[0].Context)
+}
+
+func TestWithContext(t *testing.T) {
+	logs := observeLogs(t)
+
+	ctx := WithModule(context.Background(), "ping")
+	WithContext(ctx, zap.String("key", "value")).Info("hello")
+
+	assert.Equal(t, map[string]any{
+		"module": "ping",
+		"key":    "value",
+	}, logs.All()[0].ContextMap())
+}
+
+func TestNewContext_DoesNotLeakToParent(t *testing.T) {
+	parent := NewContext(context.Background(), zap.String("a", "1"))
+	first := NewContext(parent, zap.String("b", "2"))
+	second := NewContext(parent, zap.String("c", "3"))
+
+	assert.Len(t, ContextFields(parent), 1)
+	assert.Len(t, ContextFields(first), 2)
+	assert.Len(t, ContextFields(second), 2)
+	assert.Equal(t, "c", ContextFields(second)[1].Key)
+}
@@ -0,0 +1,35 @@
+package logger
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor logs gRPC unary requests and responses
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		// Attach the method to the request context so every log of this call carries it
+		ctx = NewContext(ctx, zap.String("grpc_method", info.FullMethod))
+
+		// Log request
+		InfoContext(ctx, "gRPC Request")
+
+		// Process request
+		resp, err := handler(ctx, req)
+
+		// Log response
+		duration := time.Since(start)
+		InfoContext(ctx, "gRPC Response",
+			zap.String("status_code", status.Code(err).String()),
+			zap.Duration("duration", duration),
+		)
+
+		return resp, err
+	}
+}
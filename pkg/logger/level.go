@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// atomicLevel backs every core built by Init so the level can change without rebuilding the logger
+var atomicLevel = zap.NewAtomicLevelAt(zapcore.InfoLevel)
+
+// levelState tracks a temporary level override and the level it reverts to
+var levelState struct {
+	mu        sync.Mutex
+	base      zapcore.Level
+	timer     *time.Timer
+	expiresAt time.Time
+}
+
+// LevelStatus describes the current level and, for a temporary override, when it reverts
+type LevelStatus struct {
+	Level     string     `json:"level"`
+	BaseLevel string     `json:"base_level"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// GetLevel returns the current level and the pending revert if any
+func GetLevel() LevelStatus {
+	levelState.mu.Lock()
+	defer levelState.mu.Unlock()
+
+	status := LevelStatus{
+		Level:     atomicLevel.Level().String(),
+		BaseLevel: levelState.base.String(),
+	}
+	if levelState.timer != nil {
+		expiresAt := levelState.expiresAt
+		status.ExpiresAt = &expiresAt
+	}
+	return status
+}
+
+// SetLevel changes the level permanently, cancelling any temporary override
+func SetLevel(level zapcore.Level) {
+	levelState.mu.Lock()
+	defer levelState.mu.Unlock()
+
+	stopLevelTimerLocked()
+	levelState.base = level
+	atomicLevel.SetLevel(level)
+}
+
+// SetLevelFor changes the level for the given duration, then reverts to the permanent level
+func SetLevelFor(level zapcore.Level, duration time.Duration) {
+	levelState.mu.Lock()
+	defer levelState.mu.Unlock()
+
+	stopLevelTimerLocked()
+	atomicLevel.SetLevel(level)
+	levelState.expiresAt = time.Now().Add(duration)
+
+	var timer *time.Timer
+	timer = time.AfterFunc(duration, func() {
+		levelState.mu.Lock()
+		defer levelState.mu.Unlock()
+
+		// A newer call replaced this override
+		if levelState.timer != timer {
+			return
+		}
+		levelState.timer = nil
+		atomicLevel.SetLevel(levelState.base)
+		GetLogger().Info("Temporary log level expired", zap.String("level", levelState.base.String()))
+	})
+	levelState.timer = timer
+}
+
+// ParseLevel parses a level name such as "debug" or "warn"
+func ParseLevel(text string) (zapcore.Level, error) {
+	return zapcore.ParseLevel(text)
+}
+
+func stopLevelTimerLocked() {
+	if levelState.timer != nil {
+		levelState.timer.Stop()
+		levelState.timer = nil
+	}
+}
@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSetLevel(t *testing.T) {
+	t.Cleanup(func() { SetLevel(zapcore.InfoLevel) })
+
+	SetLevel(zapcore.WarnLevel)
+
+	status := GetLevel()
+	assert.Equal(t, "warn", status.Level)
+	assert.Equal(t, "warn", status.BaseLevel)
+	assert.Nil(t, status.ExpiresAt)
+	assert.False(t, atomicLevel.Enabled(zapcore.InfoLevel))
+}
+
+func TestSetLevelFor_Reverts(t *testing.T) {
+	t.Cleanup(func() { SetLevel(zapcore.InfoLevel) })
+
+	SetLevel(zapcore.InfoLevel)
+	SetLevelFor(zapcore.DebugLevel, 20*time.Millisecond)
+
+	status := GetLevel()
+	assert.Equal(t, "debug", status.Level)
+	assert.Equal(t, "info", status.BaseLevel)
+	assert.NotNil(t, status.ExpiresAt)
+
+	assert.Eventually(t, func() bool {
+		return GetLevel().Level == "info"
+	}, time.Second, 5*time.Millisecond)
+	assert.Nil(t, GetLevel().ExpiresAt)
+}
+
+func TestSetLevel_CancelsTemporaryOverride(t *testing.T) {
+	t.Cleanup(func() { SetLevel(zapcore.InfoLevel) })
+
+	SetLevelFor(zapcore.DebugLevel, 20*time.Millisecond)
+	SetLevel(zapcore.ErrorLevel)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, "error", GetLevel().Level)
+}
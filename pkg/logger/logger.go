@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"go-skeleton/config"
 	"log"
-	"os"
+	"log/slog"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var (
@@ -42,15 +44,26 @@ func Init(logConfig config.LoggerConfig) {
 		encoder = zapcore.NewJSONEncoder(encoderConfig)
 	}
 
-	// writeSyncer always defaults to stdout for now
-	writeSyncer := zapcore.AddSync(os.Stdout)
-
-	// Create core
-	core := zapcore.NewCore(encoder, writeSyncer, level)
+	// Output sinks receive every entry, error sinks additionally receive entries at error level and above
+	files := make(map[string]*lumberjack.Logger)
+	writeSyncer := openSinks(logConfig.OutputPaths, logConfig.File, files)
+	errorSyncer := openSinks(logConfig.ErrorOutputPaths, logConfig.File, files)
+
+	// Create core, the atomic level lets SetLevel change verbosity at runtime and every core masks sensitive fields
+	SetLevel(level)
+	core := newRedactCore(zapcore.NewCore(encoder, writeSyncer, atomicLevel))
+	if errorPaths := extraSinks(logConfig.OutputPaths, logConfig.ErrorOutputPaths); len(errorPaths) > 0 {
+		errorLevel := zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+			return l >= zapcore.ErrorLevel && atomicLevel.Enabled(l)
+		})
+		teeSyncer := openSinks(errorPaths, logConfig.File, files)
+		core = zapcore.NewTee(core, newRedactCore(zapcore.NewCore(encoder, teeSyncer, errorLevel)))
+	}
 
 	// Create a logger with options
 	opts := []zap.Option{
 		zap.AddStacktrace(zapcore.ErrorLevel),
+		zap.ErrorOutput(errorSyncer), // Internal logger errors
 	}
 
 	if !logConfig.DisableCaller {
@@ -67,6 +80,11 @@ func Init(logConfig config.LoggerConfig) {
 
 	globalLogger = zap.New(core, opts...)
 	sugar = globalLogger.Sugar()
+
+	// Route slog, and the standard log package behind it, through the same core
+	slog.SetDefault(slog.New(NewSlogHandler()))
+
+	replaceFileSinks(files, time.Duration(logConfig.File.RotateIntervalHour)*time.Hour)
 }
 
 // GetLogger returns the global zap logger
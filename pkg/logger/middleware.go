@@ -1,7 +1,6 @@
 package logger
 
 import (
-	httpcommon "go-skeleton/internal/common/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -13,13 +12,18 @@ func GinLoggingMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 
+		// Client fields are attached to the request context so every log of this request carries them,
+		// request ID and trace IDs are set by the preceding middlewares
+		ctx := NewContext(c.Request.Context(),
+			zap.String("remote_addr", c.ClientIP()),
+			zap.String("user_agent", c.Request.UserAgent()),
+		)
+		c.Request = c.Request.WithContext(ctx)
+
 		// Log request
-		GetLogger().Info("HTTP Request",
+		InfoContext(ctx, "HTTP Request",
 			zap.String("method", c.Request.Method),
 			zap.String("url", c.Request.URL.String()),
-			zap.String("remote_addr", c.ClientIP()),
-			zap.String("user_agent", c.Request.UserAgent()),
-			zap.String("request_id", c.GetHeader(httpcommon.HeaderRequestID.String())),
 		)
 
 		// Process request
@@ -27,16 +31,23 @@ func GinLoggingMiddleware() gin.HandlerFunc {
 
 		// Log response
 		duration := time.Since(start)
-		GetLogger().Info("HTTP Response",
+		InfoContext(ctx, "HTTP Response",
 			zap.String("method", c.Request.Method),
 			zap.String("url", c.Request.URL.String()),
 			zap.Int("status_code", c.Writer.Status()),
 			zap.Duration("duration", duration),
-			zap.String("request_id", c.GetHeader("X-Request-ID")),
 		)
 	}
 }
 
+// ModuleMiddleware tags every log of the request with the module name
+func ModuleMiddleware(module string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(WithModule(c.Request.Context(), module))
+		c.Next()
+	}
+}
+
 // LoggingMiddleware logs HTTP requests and responses (kept for backward compatibility)
 func LoggingMiddleware() gin.HandlerFunc {
 	return GinLoggingMiddleware()
@@ -0,0 +1,198 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go-skeleton/pkg/redact"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// redactCore masks sensitive fields and substrings before entries reach the wrapped core
+type redactCore struct {
+	zapcore.Core
+}
+
+func newRedactCore(core zapcore.Core) zapcore.Core {
+	return &redactCore{Core: core}
+}
+
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(redactFields(fields))}
+}
+
+func (c *redactCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *redactCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = redact.String(entry.Message)
+	return c.Core.Write(entry, redactFields(fields))
+}
+
+// redactFields masks fields with a sensitive key and redacts string, error and stringer values, objects, arrays
+// and reflected values are redacted key by key
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	redacted := make([]zapcore.Field, len(fields))
+	for i, field := range fields {
+		redacted[i] = redactField(field)
+	}
+	return redacted
+}
+
+func redactField(field zapcore.Field) zapcore.Field {
+	switch field.Type {
+	case zapcore.NamespaceType, zapcore.SkipType:
+		return field
+	}
+
+	if redact.IsSensitiveKey(field.Key) {
+		return zap.String(field.Key, redact.Mask)
+	}
+
+	switch field.Type {
+	case zapcore.StringType:
+		field.String = redact.String(field.String)
+	case zapcore.ByteStringType:
+		if b, ok := field.Interface.([]byte); ok {
+			return zap.String(field.Key, redact.String(string(b)))
+		}
+	case zapcore.ErrorType:
+		if err, ok := field.Interface.(error); ok {
+			return zap.String(field.Key, redact.String(err.Error()))
+		}
+	case zapcore.StringerType:
+		if stringer, ok := field.Interface.(fmt.Stringer); ok {
+			return zap.String(field.Key, redact.String(stringer.String()))
+		}
+	case zapcore.ObjectMarshalerType, zapcore.ArrayMarshalerType, zapcore.ReflectType, zapcore.InlineMarshalerType:
+		return redactComplex(field)
+	}
+	return field
+}
+
+// redactComplex rebuilds an object, array, inline or reflected field from its redacted generic form, fields with
+// nothing a rule could match are returned as they are
+func redactComplex(field zapcore.Field) zapcore.Field {
+	enc := zapcore.NewMapObjectEncoder()
+	field.AddTo(enc)
+	if !mayNeedRedaction(enc.Fields) {
+		return field
+	}
+
+	fields, ok := redactedFields(enc.Fields).(map[string]any)
+	if field.Type == zapcore.InlineMarshalerType {
+		if !ok {
+			return zap.Skip()
+		}
+		return zap.Inline(redactedObject(fields))
+	}
+	if !ok {
+		return zap.String(field.Key, redact.Mask)
+	}
+	if object, ok := fields[field.Key].(map[string]any); ok {
+		return zap.Object(field.Key, redactedObject(object))
+	}
+	return zap.Any(field.Key, fields[field.Key])
+}
+
+// mayNeedRedaction reports whether value, as stored by a MapObjectEncoder, holds a sensitive key or a string long
+// enough for a redaction rule. Reflected values are only known once encoded, so they are assumed to.
+func mayNeedRedaction(value any) bool {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if redact.IsSensitiveKey(key) || mayNeedRedaction(item) {
+				return true
+			}
+		}
+		return false
+	case []any:
+		for _, item := range v {
+			if mayNeedRedaction(item) {
+				return true
+			}
+		}
+		return false
+	case string:
+		return len(v) >= redact.MinLength()
+	case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr,
+		float32, float64, complex64, complex128, []byte, time.Duration, time.Time:
+		return false
+	}
+	return true
+}
+
+// redactedFields encodes the fields of a MapObjectEncoder the way the JSON encoder would and redacts the result,
+// nil when they cannot be encoded so nothing unredacted is logged
+func redactedFields(fields map[string]any) any {
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil
+	}
+	return redactValue(value)
+}
+
+// redactValue masks the values of sensitive keys and redacts the strings of a decoded JSON value
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if redact.IsSensitiveKey(key) {
+				v[key] = redact.Mask
+			} else {
+				v[key] = redactValue(item)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	case string:
+		return redact.String(v)
+	}
+	return value
+}
+
+// redactedObject logs a redacted JSON object with its keys sorted
+type redactedObject map[string]any
+
+func (o redactedObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	keys := make([]string, 0, len(o))
+	for key := range o {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		var err error
+		switch v := o[key].(type) {
+		case map[string]any:
+			err = enc.AddObject(key, redactedObject(v))
+		case string:
+			enc.AddString(key, v)
+		case bool:
+			enc.AddBool(key, v)
+		default:
+			err = enc.AddReflected(key, v)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
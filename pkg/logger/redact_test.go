@@ -0,0 +1,113 @@
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRedactCore(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	log := zap.New(newRedactCore(core)).With(zap.String("api_key", "abc"))
+
+	log.Info("login with password=hunter2",
+		zap.String("password", "hunter2"),
+		zap.String("header", "Bearer abc.def"),
+		zap.Error(errors.New("dial postgres://app:s3cret@db/app")),
+		zap.Int("attempt", 1),
+	)
+
+	entries := logs.All()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "login with password=[REDACTED]", entries[0].Message)
+	assert.Equal(t, map[string]any{
+		"api_key":  "[REDACTED]",
+		"password": "[REDACTED]",
+		"header":   "Bearer [REDACTED]",
+		"error":    "dial postgres://app:[REDACTED]@db/app",
+		"attempt":  int64(1),
+	}, entries[0].ContextMap())
+}
+
+func TestRedactCore_ComplexFields(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	log := zap.New(newRedactCore(core))
+
+	type credentials struct {
+		User     string `json:"user"`
+		Password string `json:"password"`
+	}
+	user := zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+		enc.AddString("name", "jane")
+		enc.AddString("email", "jane@example.com")
+		return enc.AddObject("session", zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			enc.AddString("token", "abc")
+			enc.AddInt("ttl", 60)
+			return nil
+		}))
+	})
+
+	log.Info("login",
+		zap.Any("credentials", credentials{User: "jane", Password: "hunter2"}),
+		zap.Reflect("headers", map[string]string{"Authorization": "Bearer abc.def", "Accept": "*/*"}),
+		zap.Object("user", user),
+		zap.Strings("dsns", []string{"postgres://app:s3cret@db/app"}),
+		zap.Inline(zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			enc.AddString("secret", "shh")
+			return nil
+		})),
+	)
+
+	entries := logs.All()
+	assert.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, map[string]any{"user": "jane", "password": "[REDACTED]"}, fields["credentials"])
+	assert.Equal(t, map[string]any{"Authorization": "[REDACTED]", "Accept": "*/*"}, fields["headers"])
+	assert.Equal(t, map[string]any{
+		"name":    "jane",
+		"email":   "[REDACTED]",
+		"session": map[string]any{"token": "[REDACTED]", "ttl": json.Number("60")},
+	}, fields["user"])
+	assert.Equal(t, []any{"postgres://app:[REDACTED]@db/app"}, fields["dsns"])
+	assert.Equal(t, "[REDACTED]", fields["secret"])
+}
+
+func TestRedactCore_SlogGroups(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	previous := globalLogger
+	globalLogger = zap.New(newRedactCore(core))
+	t.Cleanup(func() {
+		globalLogger = previous
+	})
+
+	slog.New(NewSlogHandler()).Info("request",
+		slog.Group("client", slog.String("token", "abc"), slog.String("contact", "jane@example.com")),
+		slog.Any("headers", map[string]string{"Cookie": "session=abc"}),
+	)
+
+	entries := logs.All()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, map[string]any{
+		"client":  map[string]any{"token": "[REDACTED]", "contact": "[REDACTED]"},
+		"headers": map[string]any{"Cookie": "[REDACTED]"},
+	}, entries[0].ContextMap())
+}
+
+func TestRedactField_SkipsFieldsWithoutMatches(t *testing.T) {
+	page := zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+		enc.AddInt("number", 2)
+		enc.AddString("sort", "asc")
+		return nil
+	})
+
+	assert.Equal(t, zapcore.ObjectMarshalerType, redactField(zap.Object("page", page)).Type)
+	assert.Equal(t, zapcore.ArrayMarshalerType, redactField(zap.Ints("ids", []int{1, 2})).Type)
+	assert.Equal(t, zapcore.ReflectType, redactField(zap.Reflect("ratio", 0.5)).Type)
+	assert.Equal(t, "ok", redactField(zap.String("status", "ok")).String)
+}
@@ -0,0 +1,144 @@
+package logger
+
+import (
+	"go-skeleton/config"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	sinkStdout = "stdout"
+	sinkStderr = "stderr"
+)
+
+// fileSinks holds the rotating file writers of the current logger so they can be rotated and closed
+var fileSinks struct {
+	mu    sync.Mutex
+	files map[string]*lumberjack.Logger
+	stop  chan struct{}
+}
+
+// openSinks combines the given paths into one write syncer, files are shared between calls through files
+func openSinks(paths []string, cfg config.LogFileConfig, files map[string]*lumberjack.Logger) zapcore.WriteSyncer {
+	syncers := make([]zapcore.WriteSyncer, 0, len(paths))
+	for _, path := range paths {
+		switch path {
+		case sinkStdout:
+			syncers = append(syncers, stdSink(os.Stdout))
+		case sinkStderr:
+			syncers = append(syncers, stdSink(os.Stderr))
+		default:
+			path = filepath.Clean(path)
+			file, ok := files[path]
+			if !ok {
+				file = &lumberjack.Logger{
+					Filename:   path,
+					MaxSize:    cfg.MaxSizeMB,
+					MaxAge:     cfg.MaxAgeDays,
+					MaxBackups: cfg.MaxBackups,
+					Compress:   cfg.Compress,
+					LocalTime:  true,
+				}
+				files[path] = file
+			}
+			syncers = append(syncers, zapcore.AddSync(file))
+		}
+	}
+
+	if len(syncers) == 0 {
+		return stdSink(os.Stdout)
+	}
+	return zapcore.NewMultiWriteSyncer(syncers...)
+}
+
+// stdSink hides Sync of a standard stream, syncing a terminal or pipe fails with EINVAL on Linux
+func stdSink(file *os.File) zapcore.WriteSyncer {
+	return zapcore.Lock(zapcore.AddSync(struct{ io.Writer }{file}))
+}
+
+// extraSinks returns the error paths that are not output paths already, errors are only teed to those so no sink
+// receives them twice
+func extraSinks(outputPaths, errorPaths []string) []string {
+	output := make([]string, 0, len(outputPaths))
+	for _, path := range outputPaths {
+		output = append(output, sinkKey(path))
+	}
+
+	var extra []string
+	for _, path := range errorPaths {
+		key := sinkKey(path)
+		if !slices.Contains(output, key) && !slices.Contains(extra, key) {
+			extra = append(extra, key)
+		}
+	}
+	return extra
+}
+
+// sinkKey normalizes file paths the way openSinks does, so the same file spelled differently is one sink
+func sinkKey(path string) string {
+	if path == sinkStdout || path == sinkStderr {
+		return path
+	}
+	return filepath.Clean(path)
+}
+
+// replaceFileSinks installs the file writers of a new logger, closing the previous ones and restarting time-based rotation
+func replaceFileSinks(files map[string]*lumberjack.Logger, interval time.Duration) {
+	fileSinks.mu.Lock()
+	defer fileSinks.mu.Unlock()
+
+	closeFileSinksLocked()
+
+	fileSinks.files = files
+	if interval <= 0 || len(files) == 0 {
+		return
+	}
+
+	stop := make(chan struct{})
+	fileSinks.stop = stop
+	go rotateEvery(interval, files, stop)
+}
+
+func rotateEvery(interval time.Duration, files map[string]*lumberjack.Logger, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			for _, file := range files {
+				if err := file.Rotate(); err != nil {
+					_, _ = os.Stderr.WriteString("failed to rotate log file " + file.Filename + ": " + err.Error() + "\n")
+				}
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Close stops time-based rotation and closes the file sinks
+func Close() {
+	fileSinks.mu.Lock()
+	defer fileSinks.mu.Unlock()
+
+	closeFileSinksLocked()
+}
+
+func closeFileSinksLocked() {
+	if fileSinks.stop != nil {
+		close(fileSinks.stop)
+		fileSinks.stop = nil
+	}
+	for _, file := range fileSinks.files {
+		_ = file.Close()
+	}
+	fileSinks.files = nil
+}
@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"go-skeleton/config"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func initForTest(t *testing.T, cfg config.LoggerConfig) {
+	t.Helper()
+
+	previous := globalLogger
+	Init(cfg)
+	t.Cleanup(func() {
+		Close()
+		globalLogger = previous
+	})
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+
+	data, err := os.ReadFile(path) // #nosec G304 -- test file inside t.TempDir
+	assert.NoError(t, err)
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func TestInit_FileSinks(t *testing.T) {
+	dir := t.TempDir()
+	appLog := filepath.Join(dir, "app.log")
+	errorLog := filepath.Join(dir, "error.log")
+
+	initForTest(t, config.LoggerConfig{
+		Level:            "info",
+		OutputPaths:      []string{appLog},
+		ErrorOutputPaths: []string{errorLog},
+		File:             config.LogFileConfig{MaxSizeMB: 1},
+	})
+
+	Info("info message")
+	Error("error message")
+
+	// Every entry goes to the output sink, errors are teed to the error sink
+	appLines := readLines(t, appLog)
+	assert.Len(t, appLines, 2)
+	assert.Contains(t, appLines[0], "info message")
+	assert.Contains(t, appLines[1], "error message")
+
+	errorLines := readLines(t, errorLog)
+	assert.Len(t, errorLines, 1)
+	assert.Contains(t, errorLines[0], "error message")
+}
+
+func TestInit_SameSinksDoNotDuplicateErrors(t *testing.T) {
+	appLog := filepath.Join(t.TempDir(), "app.log")
+
+	initForTest(t, config.LoggerConfig{
+		Level:            "info",
+		OutputPaths:      []string{appLog},
+		ErrorOutputPaths: []string{appLog},
+	})
+
+	Error("error message")
+
+	assert.Len(t, readLines(t, appLog), 1)
+}
+
+func TestInit_MultipleSinks(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.log")
+	second := filepath.Join(dir, "second.log")
+
+	initForTest(t, config.LoggerConfig{
+		Level:            "info",
+		OutputPaths:      []string{first, second},
+		ErrorOutputPaths: []string{"stderr"},
+	})
+
+	Info("info message")
+
+	assert.Len(t, readLines(t, first), 1)
+	assert.Len(t, readLines(t, second), 1)
+}
+
+func TestInit_OverlappingSinksDoNotDuplicateErrors(t *testing.T) {
+	dir := t.TempDir()
+	appLog := filepath.Join(dir, "app.log")
+	errorLog := filepath.Join(dir, "error.log")
+
+	initForTest(t, config.LoggerConfig{
+		Level:            "info",
+		OutputPaths:      []string{appLog},
+		ErrorOutputPaths: []string{appLog, errorLog},
+	})
+
+	Error("error message")
+
+	assert.Len(t, readLines(t, appLog), 1)
+	assert.Len(t, readLines(t, errorLog), 1)
+}
+
+func TestExtraSinks(t *testing.T) {
+	assert.Empty(t, extraSinks([]string{"stdout", "app.log"}, []string{"./app.log", "stdout"}))
+	assert.Equal(t, []string{"stderr"}, extraSinks([]string{"stdout"}, []string{"stderr"}))
+	assert.Equal(t, []string{"error.log"}, extraSinks([]string{"stdout", "app.log"}, []string{"app.log", "error.log", "error.log"}))
+}
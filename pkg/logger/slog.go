@@ -0,0 +1,154 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// slogHandler is a slog.Handler writing to the global zap logger, so slog records share its encoder, sinks and level
+type slogHandler struct {
+	fields []zap.Field
+}
+
+// NewSlogHandler returns a slog.Handler backed by the global zap logger, Init installs it as the slog default
+func NewSlogHandler() slog.Handler {
+	return &slogHandler{}
+}
+
+// Enabled reports whether the global logger currently logs at level
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return GetLogger().Core().Enabled(zapLevel(level))
+}
+
+// Handle writes the record with the request-scoped fields of ctx
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	entry := FromContext(ctx).Check(zapLevel(record.Level), record.Message)
+	if entry == nil {
+		return nil
+	}
+
+	if !record.Time.IsZero() {
+		entry.Time = record.Time
+	}
+
+	// Report the slog call site instead of this handler
+	if entry.Caller.Defined && record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		entry.Caller = zapcore.NewEntryCaller(frame.PC, frame.File, frame.Line, true)
+		entry.Caller.Function = frame.Function
+	}
+
+	fields := make([]zap.Field, 0, len(h.fields)+record.NumAttrs())
+	fields = append(fields, h.fields...)
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = appendAttr(fields, attr)
+		return true
+	})
+
+	entry.Write(fields...)
+	return nil
+}
+
+// WithAttrs returns a handler adding attrs to every record
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	fields := make([]zap.Field, 0, len(h.fields)+len(attrs))
+	fields = append(fields, h.fields...)
+	for _, attr := range attrs {
+		fields = appendAttr(fields, attr)
+	}
+	return &slogHandler{fields: fields}
+}
+
+// WithGroup returns a handler nesting the following attributes under name
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	fields := make([]zap.Field, 0, len(h.fields)+1)
+	fields = append(fields, h.fields...)
+	fields = append(fields, zap.Namespace(name))
+	return &slogHandler{fields: fields}
+}
+
+// zapLevel maps a slog level to the closest zap level
+func zapLevel(level slog.Level) zapcore.Level {
+	switch {
+	case level >= slog.LevelError:
+		return zapcore.ErrorLevel
+	case level >= slog.LevelWarn:
+		return zapcore.WarnLevel
+	case level >= slog.LevelInfo:
+		return zapcore.InfoLevel
+	default:
+		return zapcore.DebugLevel
+	}
+}
+
+// appendAttr converts attr to zap fields, inlining groups without a key as slog does
+func appendAttr(fields []zap.Field, attr slog.Attr) []zap.Field {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		group := attr.Value.Group()
+		if len(group) == 0 {
+			return fields
+		}
+		if attr.Key == "" {
+			for _, nested := range group {
+				fields = appendAttr(fields, nested)
+			}
+			return fields
+		}
+		return append(fields, zap.Object(attr.Key, attrGroup(group)))
+	}
+
+	return append(fields, attrField(attr))
+}
+
+func attrField(attr slog.Attr) zap.Field {
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		return zap.String(attr.Key, attr.Value.String())
+	case slog.KindInt64:
+		return zap.Int64(attr.Key, attr.Value.Int64())
+	case slog.KindUint64:
+		return zap.Uint64(attr.Key, attr.Value.Uint64())
+	case slog.KindFloat64:
+		return zap.Float64(attr.Key, attr.Value.Float64())
+	case slog.KindBool:
+		return zap.Bool(attr.Key, attr.Value.Bool())
+	case slog.KindDuration:
+		return zap.Duration(attr.Key, attr.Value.Duration())
+	case slog.KindTime:
+		return zap.Time(attr.Key, attr.Value.Time())
+	default:
+		if err, ok := attr.Value.Any().(error); ok {
+			return zap.NamedError(attr.Key, err)
+		}
+		return zap.Any(attr.Key, attr.Value.Any())
+	}
+}
+
+// attrGroup encodes a slog group as a nested object
+type attrGroup []slog.Attr
+
+func (g attrGroup) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, attr := range g {
+		for _, field := range appendAttr(nil, attr) {
+			field.AddTo(enc)
+		}
+	}
+	return nil
+}
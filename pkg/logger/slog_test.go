@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"go-skeleton/pkg/requestid"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSlogHandler(t *testing.T) {
+	logs := observeLogs(t)
+
+	ctx := requestid.NewContext(context.Background(), "abc-123")
+	slog.New(NewSlogHandler()).ErrorContext(ctx, "failed",
+		slog.String("key", "value"),
+		slog.Int("count", 3),
+		slog.Any("err", errors.New("boom")),
+	)
+
+	entries := logs.All()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, zapcore.ErrorLevel, entries[0].Level)
+	assert.Equal(t, "failed", entries[0].Message)
+	assert.Equal(t, map[string]any{
+		"request_id": "abc-123",
+		"key":        "value",
+		"count":      int64(3),
+		"err":        "boom",
+	}, entries[0].ContextMap())
+}
+
+func TestSlogHandler_AttrsAndGroups(t *testing.T) {
+	logs := observeLogs(t)
+
+	log := slog.New(NewSlogHandler()).With("module", "ping").WithGroup("http")
+	log.Info("request", slog.Int("status", 200), slog.Group("client", slog.String("ip", "127.0.0.1")))
+
+	entries := logs.All()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, map[string]any{
+		"module": "ping",
+		"http": map[string]any{
+			"status": int64(200),
+			"client": map[string]any{"ip": "127.0.0.1"},
+		},
+	}, entries[0].ContextMap())
+}
+
+func TestSlogHandler_Levels(t *testing.T) {
+	core, logs := observer.New(atomicLevel)
+	previous := globalLogger
+	globalLogger = zap.New(core)
+	t.Cleanup(func() {
+		globalLogger = previous
+		SetLevel(zapcore.InfoLevel)
+	})
+
+	handler := NewSlogHandler()
+	SetLevel(zapcore.WarnLevel)
+
+	assert.False(t, handler.Enabled(context.Background(), slog.LevelInfo))
+	assert.True(t, handler.Enabled(context.Background(), slog.LevelWarn))
+
+	assert.Equal(t, zapcore.DebugLevel, zapLevel(slog.LevelDebug-4))
+	assert.Equal(t, zapcore.InfoLevel, zapLevel(slog.LevelInfo+1))
+	assert.Equal(t, zapcore.WarnLevel, zapLevel(slog.LevelWarn))
+	assert.Equal(t, zapcore.ErrorLevel, zapLevel(slog.LevelError+4))
+	assert.Empty(t, logs.All())
+}
+
+func TestSlogHandler_KeepsRecordTime(t *testing.T) {
+	logs := observeLogs(t)
+
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	record := slog.NewRecord(at, slog.LevelInfo, "hello", 0)
+	assert.NoError(t, NewSlogHandler().Handle(context.Background(), record))
+
+	assert.Len(t, logs.All(), 1)
+	assert.True(t, at.Equal(logs.All()[0].Time))
+}
@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"database/sql"
+	"go-skeleton/config"
+	"go-skeleton/pkg/logger"
+	"net/http"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+const namespace = "skeleton"
+
+var (
+	Registry = prometheus.NewRegistry()
+
+	httpRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Total number of HTTP requests by method, route template and status code.",
+		},
+		[]string{"method", "route", "status"},
+	)
+
+	httpRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by method, route template and status code.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"method", "route", "status"},
+	)
+
+	dbQueryDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "query_duration_seconds",
+			Help:      "Database query latency by repository and operation.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"repository", "operation"},
+	)
+
+	dbQueryErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "query_errors_total",
+			Help:      "Failed database queries by repository, operation and pkg/errors/sql code.",
+		},
+		[]string{"repository", "operation", "code"},
+	)
+)
+
+// Init registers the runtime and HTTP collectors to the global registry
+func Init(cfg config.MetricsConfig) {
+	if !cfg.Enabled {
+		return
+	}
+
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestsTotal,
+		httpRequestDuration,
+		dbQueryDuration,
+		dbQueryErrorsTotal,
+	)
+}
+
+// ObserveDBQuery records the latency of a query, and counts it as failed under code when code is not empty
+func ObserveDBQuery(repository, operation string, duration time.Duration, code string) {
+	dbQueryDuration.WithLabelValues(repository, operation).Observe(duration.Seconds())
+	if code != "" {
+		dbQueryErrorsTotal.WithLabelValues(repository, operation, code).Inc()
+	}
+}
+
+// RegisterDBStats publishes the sql.DBStats of the given connection pool
+func RegisterDBStats(db *sql.DB, dbName string) {
+	if db == nil {
+		return
+	}
+	register(collectors.NewDBStatsCollector(db, dbName))
+}
+
+// RegisterRedisPoolStats publishes the redis.PoolStats of the given client
+func RegisterRedisPoolStats(client *redis.Client) {
+	if client == nil {
+		return
+	}
+	register(NewRedisPoolCollector(client))
+}
+
+// Handler returns the HTTP handler that serves the global registry in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{
+		Registry: Registry,
+	})
+}
+
+func register(collector prometheus.Collector) {
+	if err := Registry.Register(collector); err != nil {
+		logger.Error("failed to register metrics collector", zap.Error(err))
+	}
+}
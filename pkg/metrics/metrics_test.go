@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGinMetricsMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(GinMetricsMiddleware())
+	router.GET("/users/:id", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for _, path := range []string{"/users/1", "/users/2", "/missing"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	// Requests are labelled with the route template instead of the raw path
+	assert.Equal(t, float64(2), testutil.ToFloat64(httpRequestsTotal.WithLabelValues(http.MethodGet, "/users/:id", "200")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(httpRequestsTotal.WithLabelValues(http.MethodGet, unmatchedRoute, "404")))
+}
+
+func TestRedisPoolCollector(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:0"})
+	defer client.Close()
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(NewRedisPoolCollector(client))
+
+	count, err := testutil.GatherAndCount(registry)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, count)
+
+	expected := `
+# HELP skeleton_redis_pool_total_connections Number of total connections in the pool.
+# TYPE skeleton_redis_pool_total_connections gauge
+skeleton_redis_pool_total_connections 0
+`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "skeleton_redis_pool_total_connections"))
+}
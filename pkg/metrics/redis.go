@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RedisPoolCollector exposes redis.PoolStats as Prometheus metrics
+type RedisPoolCollector struct {
+	client *redis.Client
+
+	hits       *prometheus.Desc
+	misses     *prometheus.Desc
+	timeouts   *prometheus.Desc
+	totalConns *prometheus.Desc
+	idleConns  *prometheus.Desc
+	staleConns *prometheus.Desc
+}
+
+// NewRedisPoolCollector creates a collector reading the pool stats of the given client on every scrape
+func NewRedisPoolCollector(client *redis.Client) *RedisPoolCollector {
+	fqName := func(name string) string {
+		return prometheus.BuildFQName(namespace, "redis_pool", name)
+	}
+
+	return &RedisPoolCollector{
+		client:     client,
+		hits:       prometheus.NewDesc(fqName("hits_total"), "Number of times a free connection was found in the pool.", nil, nil),
+		misses:     prometheus.NewDesc(fqName("misses_total"), "Number of times a free connection was not found in the pool.", nil, nil),
+		timeouts:   prometheus.NewDesc(fqName("timeouts_total"), "Number of times a wait timeout occurred.", nil, nil),
+		totalConns: prometheus.NewDesc(fqName("total_connections"), "Number of total connections in the pool.", nil, nil),
+		idleConns:  prometheus.NewDesc(fqName("idle_connections"), "Number of idle connections in the pool.", nil, nil),
+		staleConns: prometheus.NewDesc(fqName("stale_connections_total"), "Number of stale connections removed from the pool.", nil, nil),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *RedisPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.timeouts
+	ch <- c.totalConns
+	ch <- c.idleConns
+	ch <- c.staleConns
+}
+
+// Collect implements prometheus.Collector
+func (c *RedisPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.client.PoolStats()
+
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(c.timeouts, prometheus.CounterValue, float64(stats.Timeouts))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stats.TotalConns))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stats.IdleConns))
+	ch <- prometheus.MustNewConstMetric(c.staleConns, prometheus.CounterValue, float64(stats.StaleConns))
+}
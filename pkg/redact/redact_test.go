@@ -0,0 +1,74 @@
+package redact
+
+import (
+	"go-skeleton/config"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"url dsn", "dial postgres://app:s3cret@db:5432/app", "dial postgres://app:[REDACTED]@db:5432/app"},
+		{"key value dsn", "host=db user=app password=s3cret dbname=app", "host=db user=app password=[REDACTED] dbname=app"},
+		{"quoted value", `token: "abc def"`, "token: [REDACTED]"},
+		{"authorization", "Authorization: Bearer eyJhbGciOi.J9.x", "Authorization: Bearer [REDACTED]"},
+		{"email", "user john.doe@example.com not found", "user [REDACTED] not found"},
+		{"card number", "card 4111 1111 1111 1111 declined", "card [REDACTED] declined"},
+		{"non luhn number", "order 1700000000000 created", "order 1700000000000 created"},
+		{"amex", "card 3782 822463 10005 declined", "card [REDACTED] declined"},
+		{"luhn valid nanosecond timestamp", "at 1729241234567890121", "at 1729241234567890121"},
+		{"luhn valid snowflake id", "message 1293847561029384755 sent", "message 1293847561029384755 sent"},
+		{"luhn valid number without card prefix", "order 9000123456789016 paid", "order 9000123456789016 paid"},
+		{"plain", "record not found", "record not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, String(tt.input))
+		})
+	}
+}
+
+func TestIsSensitiveKey(t *testing.T) {
+	assert.True(t, IsSensitiveKey("password"))
+	assert.True(t, IsSensitiveKey("DB_PASSWORD"))
+	assert.True(t, IsSensitiveKey("Authorization"))
+	assert.True(t, IsSensitiveKey("x-api-key"))
+	assert.False(t, IsSensitiveKey("request_id"))
+	assert.False(t, IsSensitiveKey("user_agent"))
+}
+
+func TestInit_CustomFieldsAndPatterns(t *testing.T) {
+	t.Cleanup(func() { _ = Init(config.RedactConfig{}) })
+
+	err := Init(config.RedactConfig{
+		Fields:   []string{"national_id"},
+		Patterns: []string{`NIK-\d+`},
+	})
+	assert.NoError(t, err)
+
+	assert.True(t, IsSensitiveKey("national_id"))
+	assert.Equal(t, "citizen [REDACTED]", String("citizen NIK-12345"))
+}
+
+func TestMinLength(t *testing.T) {
+	t.Cleanup(func() { _ = Init(config.RedactConfig{}) })
+
+	// "pwd=x" is the shortest text the default rules change
+	assert.Equal(t, 5, MinLength())
+	assert.Equal(t, "pwd=", String("pwd="))
+
+	assert.NoError(t, Init(config.RedactConfig{Patterns: []string{`K\d`}}))
+	assert.Equal(t, 2, MinLength())
+	assert.Equal(t, "[REDACTED]", String("K1"))
+}
+
+func TestInit_InvalidPattern(t *testing.T) {
+	err := Init(config.RedactConfig{Patterns: []string{"("}})
+	assert.Error(t, err)
+}
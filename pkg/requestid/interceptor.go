@@ -0,0 +1,25 @@
+package requestid
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryServerInterceptor reuses the incoming request ID metadata or generates one, stores it in the context and echoes it in the response header
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var incoming string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(HeaderName); len(values) > 0 {
+				incoming = values[0]
+			}
+		}
+
+		id := Resolve(incoming)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(HeaderName, id))
+
+		return handler(NewContext(ctx, id), req)
+	}
+}
@@ -0,0 +1,17 @@
+package requestid
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// GinMiddleware reuses the incoming request ID or generates one, stores it in the request context and echoes it in the response
+func GinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := Resolve(c.GetHeader(HeaderName))
+
+		c.Request = c.Request.WithContext(NewContext(c.Request.Context(), id))
+		c.Header(HeaderName, id)
+
+		c.Next()
+	}
+}
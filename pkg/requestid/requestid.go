@@ -0,0 +1,59 @@
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// HeaderName is the header used to receive and echo the request ID
+const HeaderName = "x-request-id"
+
+// maxLength bounds IDs accepted from clients so they cannot flood the logs
+const maxLength = 128
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the request ID
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID stored in ctx, or an empty string when there is none
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Generate returns a new time-ordered UUIDv7 request ID
+func Generate() string {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return uuid.NewString()
+	}
+	return id.String()
+}
+
+// Resolve returns the incoming ID when it is usable, otherwise a newly generated one
+func Resolve(incoming string) string {
+	if isValid(incoming) {
+		return incoming
+	}
+	return Generate()
+}
+
+// isValid accepts non-empty, bounded IDs made of printable ASCII characters
+func isValid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
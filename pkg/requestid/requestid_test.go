@@ -0,0 +1,66 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	id, err := uuid.Parse(Generate())
+
+	assert.NoError(t, err)
+	assert.Equal(t, uuid.Version(7), id.Version())
+}
+
+func TestResolve(t *testing.T) {
+	// Valid incoming IDs are kept
+	assert.Equal(t, "abc-123", Resolve("abc-123"))
+
+	// Missing, oversized or non-printable IDs are replaced
+	for _, incoming := range []string{"", strings.Repeat("a", maxLength+1), "abc 123", "abc\n123"} {
+		resolved := Resolve(incoming)
+		assert.NotEqual(t, incoming, resolved)
+		assert.NotEmpty(t, resolved)
+	}
+}
+
+func TestContext(t *testing.T) {
+	ctx := NewContext(context.Background(), "abc-123")
+
+	assert.Equal(t, "abc-123", FromContext(ctx))
+	assert.Empty(t, FromContext(context.Background()))
+}
+
+func TestGinMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var fromHandler string
+	router := gin.New()
+	router.Use(GinMiddleware())
+	router.GET("/", func(c *gin.Context) {
+		fromHandler = FromContext(c.Request.Context())
+	})
+
+	// Propagates the incoming ID
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Request-ID", "abc-123")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, "abc-123", fromHandler)
+	assert.Equal(t, "abc-123", w.Header().Get(HeaderName))
+
+	// Generates one when missing
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.NotEmpty(t, fromHandler)
+	assert.Equal(t, fromHandler, w.Header().Get(HeaderName))
+}
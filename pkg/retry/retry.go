@@ -0,0 +1,94 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"go-skeleton/config"
+	"go-skeleton/pkg/logger"
+	"math/rand/v2"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Policy is an exponential backoff bounded by a total deadline
+type Policy struct {
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+	// Jitter randomizes every delay by up to this fraction so restarted replicas do not retry in lockstep
+	Jitter float64
+	// Timeout bounds all attempts together, 0 makes a single attempt
+	Timeout time.Duration
+}
+
+// NewPolicy returns the startup retry policy of cfg
+func NewPolicy(cfg config.StartupConfig) Policy {
+	return Policy{
+		InitialInterval: cfg.RetryInitialInterval,
+		MaxInterval:     cfg.RetryMaxInterval,
+		Multiplier:      cfg.RetryMultiplier,
+		Jitter:          cfg.RetryJitter,
+		Timeout:         cfg.RetryTimeout,
+	}
+}
+
+// Do calls fn until it succeeds, ctx is done or the policy timeout elapses, logging every failed attempt.
+// The returned error wraps the error of the last attempt.
+func Do(ctx context.Context, name string, policy Policy, fn func(ctx context.Context) error) error {
+	deadline := time.Now().Add(policy.Timeout)
+	if policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			if attempt > 1 {
+				logger.Info("dependency reachable", zap.String("dependency", name), zap.Int("attempt", attempt))
+			}
+			return nil
+		}
+
+		wait := policy.delay(attempt, rand.Float64())
+		if policy.Timeout <= 0 || time.Now().Add(wait).After(deadline) {
+			return fmt.Errorf("%s not reachable after %d attempt(s): %w", name, attempt, err)
+		}
+
+		logger.Warn("dependency not reachable, retrying",
+			zap.String("dependency", name),
+			zap.Int("attempt", attempt),
+			zap.Duration("retry_in", wait),
+			zap.Error(err),
+		)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%s not reachable after %d attempt(s): %w", name, attempt, err)
+		case <-timer.C:
+		}
+	}
+}
+
+// delay returns the wait after the given failed attempt, random is a number in [0, 1)
+func (p Policy) delay(attempt int, random float64) time.Duration {
+	d := float64(p.InitialInterval)
+	for i := 1; i < attempt; i++ {
+		d *= max(p.Multiplier, 1)
+		if p.MaxInterval > 0 && d >= float64(p.MaxInterval) {
+			d = float64(p.MaxInterval)
+			break
+		}
+	}
+
+	// Spread the delay over [d - jitter*d, d + jitter*d]
+	d += d * p.Jitter * (2*random - 1)
+	if p.MaxInterval > 0 {
+		d = min(d, float64(p.MaxInterval))
+	}
+	return time.Duration(d)
+}
@@ -0,0 +1,72 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelay(t *testing.T) {
+	policy := Policy{InitialInterval: 100 * time.Millisecond, MaxInterval: time.Second, Multiplier: 2}
+
+	assert.Equal(t, 100*time.Millisecond, policy.delay(1, 0.5))
+	assert.Equal(t, 200*time.Millisecond, policy.delay(2, 0.5))
+	assert.Equal(t, 800*time.Millisecond, policy.delay(4, 0.5))
+	assert.Equal(t, time.Second, policy.delay(10, 0.5))
+}
+
+func TestDelay_Jitter(t *testing.T) {
+	policy := Policy{InitialInterval: 100 * time.Millisecond, MaxInterval: time.Second, Multiplier: 2, Jitter: 0.5}
+
+	assert.Equal(t, 50*time.Millisecond, policy.delay(1, 0))
+	assert.Equal(t, 150*time.Millisecond, policy.delay(1, 1))
+	assert.Equal(t, time.Second, policy.delay(10, 1))
+}
+
+func TestDo_RetriesUntilSuccess(t *testing.T) {
+	policy := Policy{InitialInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond, Multiplier: 2, Timeout: time.Second}
+	attempts := 0
+
+	err := Do(context.Background(), "postgres", policy, func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestDo_Timeout(t *testing.T) {
+	policy := Policy{InitialInterval: 10 * time.Millisecond, MaxInterval: 10 * time.Millisecond, Multiplier: 1, Timeout: 50 * time.Millisecond}
+	refused := errors.New("connection refused")
+	attempts := 0
+
+	start := time.Now()
+	err := Do(context.Background(), "postgres", policy, func(ctx context.Context) error {
+		attempts++
+		return refused
+	})
+
+	assert.ErrorIs(t, err, refused)
+	assert.ErrorContains(t, err, "postgres not reachable after")
+	assert.Greater(t, attempts, 1)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestDo_NoTimeoutTriesOnce(t *testing.T) {
+	attempts := 0
+
+	err := Do(context.Background(), "redis", Policy{InitialInterval: time.Millisecond}, func(ctx context.Context) error {
+		attempts++
+		return errors.New("connection refused")
+	})
+
+	assert.ErrorContains(t, err, "redis not reachable after 1 attempt(s)")
+	assert.Equal(t, 1, attempts)
+}
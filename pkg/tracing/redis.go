@@ -0,0 +1,86 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RedisHook creates a client span around every go-redis command and pipeline
+type RedisHook struct {
+	tracer trace.Tracer
+}
+
+var _ redis.Hook = (*RedisHook)(nil)
+
+// NewRedisHook creates a hook using the given tracer, a nil tracer falls back to the application tracer
+func NewRedisHook(tracer trace.Tracer) *RedisHook {
+	if tracer == nil {
+		tracer = Tracer()
+	}
+	return &RedisHook{
+		tracer: tracer,
+	}
+}
+
+func (h *RedisHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	ctx, _ = h.tracer.Start(ctx, "redis "+cmd.FullName(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemRedis,
+			semconv.DBOperationName(cmd.FullName()),
+		),
+	)
+	return ctx, nil
+}
+
+func (h *RedisHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	span := trace.SpanFromContext(ctx)
+	recordRedisError(span, cmd.Err())
+	span.End()
+	return nil
+}
+
+func (h *RedisHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	names := make([]string, 0, len(cmds))
+	for _, cmd := range cmds {
+		names = append(names, cmd.FullName())
+	}
+
+	ctx, _ = h.tracer.Start(ctx, "redis pipeline",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemRedis,
+			semconv.DBOperationName(strings.Join(names, " ")),
+			attribute.Int("db.redis.num_cmd", len(cmds)),
+		),
+	)
+	return ctx, nil
+}
+
+func (h *RedisHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	span := trace.SpanFromContext(ctx)
+	for _, cmd := range cmds {
+		if err := cmd.Err(); err != nil {
+			recordRedisError(span, err)
+			break
+		}
+	}
+	span.End()
+	return nil
+}
+
+// recordRedisError marks the span as failed, redis.Nil only means a missing key and is not an error
+func recordRedisError(span trace.Span, err error) {
+	if err == nil || errors.Is(err, redis.Nil) {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newTestHook() (*RedisHook, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	return NewRedisHook(provider.Tracer("test")), recorder
+}
+
+func TestRedisHook_Process(t *testing.T) {
+	hook, recorder := newTestHook()
+	ctx := context.Background()
+	cmd := redis.NewStringCmd(ctx, "get", "key")
+
+	ctx, err := hook.BeforeProcess(ctx, cmd)
+	assert.NoError(t, err)
+	assert.NoError(t, hook.AfterProcess(ctx, cmd))
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "redis get", spans[0].Name())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+}
+
+func TestRedisHook_Process_Error(t *testing.T) {
+	hook, recorder := newTestHook()
+	ctx := context.Background()
+	cmd := redis.NewStringCmd(ctx, "get", "key")
+	cmd.SetErr(errors.New("connection refused"))
+
+	ctx, _ = hook.BeforeProcess(ctx, cmd)
+	_ = hook.AfterProcess(ctx, cmd)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "connection refused", spans[0].Status().Description)
+}
+
+func TestRedisHook_Process_NilIsNotError(t *testing.T) {
+	hook, recorder := newTestHook()
+	ctx := context.Background()
+	cmd := redis.NewStringCmd(ctx, "get", "missing")
+	cmd.SetErr(redis.Nil)
+
+	ctx, _ = hook.BeforeProcess(ctx, cmd)
+	_ = hook.AfterProcess(ctx, cmd)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+}
+
+func TestRedisHook_ProcessPipeline(t *testing.T) {
+	hook, recorder := newTestHook()
+	ctx := context.Background()
+	cmds := []redis.Cmder{
+		redis.NewStatusCmd(ctx, "set", "key", "value"),
+		redis.NewIntCmd(ctx, "expire", "key", 10),
+	}
+
+	ctx, err := hook.BeforeProcessPipeline(ctx, cmds)
+	assert.NoError(t, err)
+	assert.NoError(t, hook.AfterProcessPipeline(ctx, cmds))
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "redis pipeline", spans[0].Name())
+}
@@ -0,0 +1,94 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"go-skeleton/config"
+	"go-skeleton/pkg/logger"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const instrumentationName = "go-skeleton"
+
+var provider *sdktrace.TracerProvider
+
+// Init installs the global tracer provider and the W3C trace context propagator
+func Init(cfg config.TracingConfig) {
+	// Always propagate traceparent so upstream traces are continued even when exporting is disabled
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if cfg.Exporter == config.TracingExporterNone || cfg.Exporter == "" {
+		logger.Info("Tracing export disabled")
+		return
+	}
+
+	exporter, err := newExporter(cfg)
+	if err != nil {
+		logger.Fatal("failed to create trace exporter", zap.Error(err))
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		logger.Fatal("failed to create trace resource", zap.Error(err))
+	}
+
+	provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	logger.Info("Tracing initialized",
+		zap.String("exporter", cfg.Exporter),
+		zap.String("service_name", cfg.ServiceName),
+		zap.Float64("sample_ratio", cfg.SampleRatio),
+	)
+}
+
+func newExporter(cfg config.TracingConfig) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case config.TracingExporterStdout:
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case config.TracingExporterOTLP:
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(cfg.OTLPEndpoint),
+		}
+		if cfg.OTLPInsecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(context.Background(), opts...)
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", cfg.Exporter)
+	}
+}
+
+// Tracer returns the application tracer from the global provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Shutdown flushes pending spans and stops the exporter
+func Shutdown(ctx context.Context) {
+	if provider == nil {
+		return
+	}
+	if err := provider.Shutdown(ctx); err != nil {
+		logger.Error("failed to shutdown tracer provider", zap.Error(err))
+	}
+}